@@ -1,6 +1,7 @@
 package hash_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/gosuda/stdx/listx"
@@ -121,8 +122,8 @@ func testDequeRemoveFirst(t *testing.T, factory func() listx.Deque[int]) {
 	// Test empty deque
 	d.Clear()
 	result = d.RemoveFirst()
-	if result.IsOk() {
-		t.Error("RemoveFirst on empty deque should return Err")
+	if result.IsOk() || !errors.Is(result.UnwrapErr(), listx.ErrEmpty) {
+		t.Error("RemoveFirst on empty deque should return ErrEmpty")
 	}
 }
 
@@ -149,8 +150,8 @@ func testDequeRemoveLast(t *testing.T, factory func() listx.Deque[int]) {
 	// Test empty deque
 	d.Clear()
 	result = d.RemoveLast()
-	if result.IsOk() {
-		t.Error("RemoveLast on empty deque should return Err")
+	if result.IsOk() || !errors.Is(result.UnwrapErr(), listx.ErrEmpty) {
+		t.Error("RemoveLast on empty deque should return ErrEmpty")
 	}
 }
 
@@ -1,7 +1,6 @@
 package hash
 
 import (
-	"errors"
 	"reflect"
 
 	"github.com/gosuda/stdx/listx"
@@ -34,7 +33,7 @@ func (h *HashList[T]) Add(element T) {
 // Insert inserts an element at the specified index.
 func (h *HashList[T]) Insert(index int, element T) error {
 	if index < 0 || index > h.size {
-		return errors.New("index out of bounds")
+		return listx.ErrIndexOutOfBounds
 	}
 
 	if index == h.size {
@@ -69,7 +68,7 @@ func (h *HashList[T]) Get(index int) option.Option[T] {
 // Set sets the element at the specified index to a new value.
 func (h *HashList[T]) Set(index int, element T) error {
 	if index < 0 || index >= h.size {
-		return errors.New("index out of bounds")
+		return listx.ErrIndexOutOfBounds
 	}
 
 	h.elements[index] = element
@@ -79,7 +78,7 @@ func (h *HashList[T]) Set(index int, element T) error {
 // Remove removes the element at the specified index.
 func (h *HashList[T]) Remove(index int) result.Result[T, error] {
 	if index < 0 || index >= h.size {
-		return result.Err[T, error](errors.New("index out of bounds"))
+		return result.Err[T, error](listx.ErrIndexOutOfBounds)
 	}
 
 	removedElement := h.elements[index]
@@ -1,7 +1,6 @@
 package linked
 
 import (
-	"errors"
 	"reflect"
 
 	"github.com/gosuda/stdx/listx"
@@ -46,7 +45,7 @@ func (l *LinkedList[T]) Add(element T) {
 // Insert inserts an element at the specified index.
 func (l *LinkedList[T]) Insert(index int, element T) error {
 	if index < 0 || index > l.size {
-		return errors.New("index out of bounds")
+		return listx.ErrIndexOutOfBounds
 	}
 
 	if index == l.size {
@@ -85,7 +84,7 @@ func (l *LinkedList[T]) Get(index int) option.Option[T] {
 // Set sets the element at the specified index to a new value.
 func (l *LinkedList[T]) Set(index int, element T) error {
 	if index < 0 || index >= l.size {
-		return errors.New("index out of bounds")
+		return listx.ErrIndexOutOfBounds
 	}
 
 	node := l.getNodeAt(index)
@@ -96,7 +95,7 @@ func (l *LinkedList[T]) Set(index int, element T) error {
 // Remove removes the element at the specified index.
 func (l *LinkedList[T]) Remove(index int) result.Result[T, error] {
 	if index < 0 || index >= l.size {
-		return result.Err[T, error](errors.New("index out of bounds"))
+		return result.Err[T, error](listx.ErrIndexOutOfBounds)
 	}
 
 	var removedValue T
@@ -1,6 +1,7 @@
 package linked_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/gosuda/stdx/listx"
@@ -129,13 +130,13 @@ func testListInsert(t *testing.T, factory func() listx.List[int]) {
 
 	// Test out of bounds
 	err = l.Insert(-1, 100)
-	if err == nil {
-		t.Error("Insert with negative index should fail")
+	if !errors.Is(err, listx.ErrIndexOutOfBounds) {
+		t.Error("Insert with negative index should return ErrIndexOutOfBounds")
 	}
 
 	err = l.Insert(10, 100)
-	if err == nil {
-		t.Error("Insert with too large index should fail")
+	if !errors.Is(err, listx.ErrIndexOutOfBounds) {
+		t.Error("Insert with too large index should return ErrIndexOutOfBounds")
 	}
 }
 
@@ -180,13 +181,13 @@ func testListSet(t *testing.T, factory func() listx.List[int]) {
 
 	// Test out of bounds
 	err = l.Set(-1, 100)
-	if err == nil {
-		t.Error("Set with negative index should fail")
+	if !errors.Is(err, listx.ErrIndexOutOfBounds) {
+		t.Error("Set with negative index should return ErrIndexOutOfBounds")
 	}
 
 	err = l.Set(3, 100)
-	if err == nil {
-		t.Error("Set with too large index should fail")
+	if !errors.Is(err, listx.ErrIndexOutOfBounds) {
+		t.Error("Set with too large index should return ErrIndexOutOfBounds")
 	}
 }
 
@@ -219,13 +220,13 @@ func testListRemove(t *testing.T, factory func() listx.List[int]) {
 
 	// Test out of bounds
 	result = l.Remove(-1)
-	if result.IsOk() {
-		t.Error("Remove with negative index should return error")
+	if result.IsOk() || !errors.Is(result.UnwrapErr(), listx.ErrIndexOutOfBounds) {
+		t.Error("Remove with negative index should return ErrIndexOutOfBounds")
 	}
 
 	result = l.Remove(2)
-	if result.IsOk() {
-		t.Error("Remove with too large index should return error")
+	if result.IsOk() || !errors.Is(result.UnwrapErr(), listx.ErrIndexOutOfBounds) {
+		t.Error("Remove with too large index should return ErrIndexOutOfBounds")
 	}
 }
 
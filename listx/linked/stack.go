@@ -1,8 +1,6 @@
 package linked
 
 import (
-	"errors"
-
 	"github.com/gosuda/stdx/listx"
 	"github.com/gosuda/stdx/option"
 	"github.com/gosuda/stdx/result"
@@ -30,7 +28,7 @@ func (s *LinkedStack[T]) Push(element T) {
 // Pop removes and returns the top element of the stack.
 func (s *LinkedStack[T]) Pop() result.Result[T, error] {
 	if s.IsEmpty() {
-		return result.Err[T, error](errors.New("stack is empty"))
+		return result.Err[T, error](listx.ErrEmpty)
 	}
 	return s.list.Remove(0) // Remove from beginning (top of stack)
 }
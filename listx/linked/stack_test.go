@@ -1,6 +1,7 @@
 package linked_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/gosuda/stdx/listx"
@@ -90,8 +91,8 @@ func testStackPop(t *testing.T, factory func() listx.Stack[int]) {
 
 	// Test empty stack
 	result = s.Pop()
-	if result.IsOk() {
-		t.Error("Pop on empty stack should return error")
+	if result.IsOk() || !errors.Is(result.UnwrapErr(), listx.ErrEmpty) {
+		t.Error("Pop on empty stack should return ErrEmpty")
 	}
 }
 
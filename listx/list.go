@@ -1,10 +1,20 @@
 package listx
 
 import (
+	"errors"
+
 	"github.com/gosuda/stdx/option"
 	"github.com/gosuda/stdx/result"
 )
 
+var (
+	// ErrEmpty is returned when removing from an empty queue, deque or stack.
+	ErrEmpty = errors.New("collection is empty")
+
+	// ErrIndexOutOfBounds is returned when an index is outside a list.
+	ErrIndexOutOfBounds = errors.New("index out of bounds")
+)
+
 // List interface defines basic operations for ordered collections.
 type List[T any] interface {
 	// Add appends an element to the end of the list.
@@ -1,8 +1,6 @@
 package slices
 
 import (
-	"errors"
-
 	"github.com/gosuda/stdx/listx"
 	"github.com/gosuda/stdx/option"
 	"github.com/gosuda/stdx/result"
@@ -35,7 +33,7 @@ func (d *SliceDeque[T]) AddLast(element T) {
 // RemoveFirst removes and returns the first element of the deque.
 func (d *SliceDeque[T]) RemoveFirst() result.Result[T, error] {
 	if d.IsEmpty() {
-		return result.Err[T, error](errors.New("deque is empty"))
+		return result.Err[T, error](listx.ErrEmpty)
 	}
 	return d.Remove(0)
 }
@@ -43,7 +41,7 @@ func (d *SliceDeque[T]) RemoveFirst() result.Result[T, error] {
 // RemoveLast removes and returns the last element of the deque.
 func (d *SliceDeque[T]) RemoveLast() result.Result[T, error] {
 	if d.IsEmpty() {
-		return result.Err[T, error](errors.New("deque is empty"))
+		return result.Err[T, error](listx.ErrEmpty)
 	}
 	return d.Remove(d.Size() - 1)
 }
@@ -1,6 +1,7 @@
 package slices_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/gosuda/stdx/listx"
@@ -109,8 +110,8 @@ func testDequeRemoveFirst(t *testing.T, factory func() listx.Deque[int]) {
 	// Test empty deque
 	d.Clear()
 	result = d.RemoveFirst()
-	if result.IsOk() {
-		t.Error("RemoveFirst on empty deque should return error")
+	if result.IsOk() || !errors.Is(result.UnwrapErr(), listx.ErrEmpty) {
+		t.Error("RemoveFirst on empty deque should return ErrEmpty")
 	}
 }
 
@@ -137,8 +138,8 @@ func testDequeRemoveLast(t *testing.T, factory func() listx.Deque[int]) {
 	// Test empty deque
 	d.Clear()
 	result = d.RemoveLast()
-	if result.IsOk() {
-		t.Error("RemoveLast on empty deque should return error")
+	if result.IsOk() || !errors.Is(result.UnwrapErr(), listx.ErrEmpty) {
+		t.Error("RemoveLast on empty deque should return ErrEmpty")
 	}
 }
 
@@ -1,7 +1,6 @@
 package slices
 
 import (
-	"errors"
 	"reflect"
 
 	"github.com/gosuda/stdx/listx"
@@ -31,7 +30,7 @@ func (s *SliceList[T]) Add(element T) {
 // Insert inserts an element at the specified index.
 func (s *SliceList[T]) Insert(index int, element T) error {
 	if index < 0 || index > len(s.elements) {
-		return errors.New("index out of bounds")
+		return listx.ErrIndexOutOfBounds
 	}
 
 	if index == len(s.elements) {
@@ -62,7 +61,7 @@ func (s *SliceList[T]) Get(index int) option.Option[T] {
 // Set sets the element at the specified index to a new value.
 func (s *SliceList[T]) Set(index int, element T) error {
 	if index < 0 || index >= len(s.elements) {
-		return errors.New("index out of bounds")
+		return listx.ErrIndexOutOfBounds
 	}
 	s.elements[index] = element
 	return nil
@@ -71,7 +70,7 @@ func (s *SliceList[T]) Set(index int, element T) error {
 // Remove removes the element at the specified index.
 func (s *SliceList[T]) Remove(index int) result.Result[T, error] {
 	if index < 0 || index >= len(s.elements) {
-		return result.Err[T, error](errors.New("index out of bounds"))
+		return result.Err[T, error](listx.ErrIndexOutOfBounds)
 	}
 
 	removedElement := s.elements[index]
@@ -1,8 +1,6 @@
 package slices
 
 import (
-	"errors"
-
 	"github.com/gosuda/stdx/listx"
 	"github.com/gosuda/stdx/option"
 	"github.com/gosuda/stdx/result"
@@ -30,7 +28,7 @@ func (q *SliceQueue[T]) Enqueue(element T) {
 // Dequeue removes and returns the front element of the queue.
 func (q *SliceQueue[T]) Dequeue() result.Result[T, error] {
 	if q.IsEmpty() {
-		return result.Err[T, error](errors.New("queue is empty"))
+		return result.Err[T, error](listx.ErrEmpty)
 	}
 	return q.list.Remove(0) // Remove from beginning (front of queue)
 }
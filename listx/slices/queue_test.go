@@ -1,6 +1,7 @@
 package slices_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/gosuda/stdx/listx"
@@ -90,8 +91,8 @@ func testQueueDequeue(t *testing.T, factory func() listx.Queue[int]) {
 
 	// Test empty queue
 	result = q.Dequeue()
-	if result.IsOk() {
-		t.Error("Dequeue on empty queue should return error")
+	if result.IsOk() || !errors.Is(result.UnwrapErr(), listx.ErrEmpty) {
+		t.Error("Dequeue on empty queue should return ErrEmpty")
 	}
 }
 
@@ -1,8 +1,6 @@
 package slices
 
 import (
-	"errors"
-
 	"github.com/gosuda/stdx/listx"
 	"github.com/gosuda/stdx/option"
 	"github.com/gosuda/stdx/result"
@@ -30,7 +28,7 @@ func (s *SliceStack[T]) Push(element T) {
 // Pop removes and returns the top element of the stack.
 func (s *SliceStack[T]) Pop() result.Result[T, error] {
 	if s.IsEmpty() {
-		return result.Err[T, error](errors.New("stack is empty"))
+		return result.Err[T, error](listx.ErrEmpty)
 	}
 	return s.list.Remove(s.list.Size() - 1) // Remove from end (top of stack)
 }
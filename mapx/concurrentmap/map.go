@@ -1,175 +1,168 @@
 package concurrentmap
 
 import (
-	"errors"
 	"reflect"
 	"sync"
+	"sync/atomic"
 
 	"github.com/gosuda/stdx/mapx"
+	"github.com/gosuda/stdx/mapx/persistent"
 	"github.com/gosuda/stdx/option"
 	"github.com/gosuda/stdx/result"
 )
 
 var _ mapx.Map[int, string] = (*ConcurrentMap[int, string])(nil)
 
+// ConcurrentMap is a thread-safe map that publishes immutable versions of a persistent hash trie.
+//
+// Every method is linearizable:
+//   - Get, ContainsKey, ContainsValue, Size, IsEmpty, Keys, Values, Entries, ForEach, FindKey,
+//     FindEntry, Filter and Snapshot atomically load the current version and work on it alone,
+//     so each call observes exactly the state after some completed write.
+//   - Put, Remove and Clear are serialized and atomically publish a new version.
+//
+// Separate calls may observe different versions. Use Snapshot to read several properties,
+// such as Keys and Values, from the same point in time.
+//
+// Writers hold a single mutex while copying the path to the changed entry, so concurrent
+// Put and Remove calls run one at a time.
 type ConcurrentMap[K comparable, V any] struct {
-	elements sync.Map // Using sync.Map for concurrent access
+	mu      sync.Mutex // serializes writers
+	current atomic.Pointer[persistent.Map[K, V]]
 }
 
 func New[K comparable, V any]() *ConcurrentMap[K, V] {
-	return &ConcurrentMap[K, V]{}
+	c := &ConcurrentMap[K, V]{}
+	c.current.Store(persistent.New[K, V]())
+	return c
+}
+
+// load returns the current version, initializing the zero value on first use.
+func (c *ConcurrentMap[K, V]) load() *persistent.Map[K, V] {
+	if m := c.current.Load(); m != nil {
+		return m
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.current.Load() == nil {
+		c.current.Store(persistent.New[K, V]())
+	}
+	return c.current.Load()
+}
+
+// update atomically replaces the current version with the one returned by fn.
+func (c *ConcurrentMap[K, V]) update(fn func(*persistent.Map[K, V]) *persistent.Map[K, V]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	m := c.current.Load()
+	if m == nil {
+		m = persistent.New[K, V]()
+	}
+	c.current.Store(fn(m))
+}
+
+// Snapshot returns an immutable point-in-time view of the map in O(1).
+// Later writes to the map are not visible through the snapshot, and mutating it
+// panics with mapx.ErrReadOnly or returns it from Remove.
+func (c *ConcurrentMap[K, V]) Snapshot() *persistent.ReadOnlyMap[K, V] {
+	return c.load().ReadOnly()
 }
 
 // Clear implements mapx.Map.
 func (c *ConcurrentMap[K, V]) Clear() {
-	c.elements.Clear() // Clear the sync.Map
+	c.update(func(m *persistent.Map[K, V]) *persistent.Map[K, V] {
+		return m.Clear()
+	})
 }
 
 // ContainsKey implements mapx.Map.
 func (c *ConcurrentMap[K, V]) ContainsKey(key K) bool {
-	_, exists := c.elements.Load(key)
-	return exists
+	return c.load().ContainsKey(key)
 }
 
 // ContainsValue implements mapx.Map.
 func (c *ConcurrentMap[K, V]) ContainsValue(value V) bool {
-	found := false
-	c.elements.Range(func(key, val any) bool {
-		if reflect.DeepEqual(val.(V), value) {
-			found = true
-			return false // stop iteration
-		}
-		return true
-	})
-	return found
+	return c.FindKey(value).IsSome()
 }
 
 // Entries implements mapx.Map.
 func (c *ConcurrentMap[K, V]) Entries() []mapx.Entry[K, V] {
-	var result []mapx.Entry[K, V]
-	c.elements.Range(func(key, value any) bool {
-		result = append(result, mapx.Entry[K, V]{
-			Key:   key.(K),
-			Value: value.(V),
-		})
-		return true
-	})
-	return result
+	return c.Snapshot().Entries()
 }
 
-// ForEach implements mapx.Map.
+// ForEach implements mapx.Map. fn observes a single version and may modify the map.
 func (c *ConcurrentMap[K, V]) ForEach(fn func(key K, value V)) {
-	c.elements.Range(func(key, value any) bool {
-		fn(key.(K), value.(V))
-		return true
-	})
+	c.load().ForEach(fn)
 }
 
 // Get implements mapx.Map.
 func (c *ConcurrentMap[K, V]) Get(key K) option.Option[V] {
-	if val, exists := c.elements.Load(key); exists {
-		return option.Some(val.(V))
-	}
-	return option.None[V]()
+	return c.load().Get(key)
 }
 
 // IsEmpty implements mapx.Map.
 func (c *ConcurrentMap[K, V]) IsEmpty() bool {
-	isEmpty := true
-	c.elements.Range(func(key, value any) bool {
-		isEmpty = false
-		return false // stop iteration
-	})
-	return isEmpty
+	return c.load().IsEmpty()
 }
 
 // Keys implements mapx.Map.
 func (c *ConcurrentMap[K, V]) Keys() []K {
-	var result []K
-	c.elements.Range(func(key, value any) bool {
-		result = append(result, key.(K))
-		return true
-	})
-	return result
+	return c.Snapshot().Keys()
 }
 
 // Put implements mapx.Map.
 func (c *ConcurrentMap[K, V]) Put(key K, value V) option.Option[V] {
-	if val, loaded := c.elements.LoadOrStore(key, value); loaded {
-		// Key existed, update with new value and return previous value
-		c.elements.Store(key, value)
-		return option.Some(val.(V))
-	}
-	// Key didn't exist, new entry created
-	return option.None[V]()
+	var previous option.Option[V]
+	c.update(func(m *persistent.Map[K, V]) *persistent.Map[K, V] {
+		next, old := m.Put(key, value)
+		previous = old
+		return next
+	})
+	return previous
 }
 
 // Remove implements mapx.Map.
 func (c *ConcurrentMap[K, V]) Remove(key K) result.Result[V, error] {
-	if val, loaded := c.elements.LoadAndDelete(key); loaded {
-		return result.Ok[V, error](val.(V))
+	var removed option.Option[V]
+	c.update(func(m *persistent.Map[K, V]) *persistent.Map[K, V] {
+		next, old := m.Remove(key)
+		removed = old
+		return next
+	})
+	if removed.IsNone() {
+		return result.Err[V, error](mapx.ErrKeyNotFound)
 	}
-	return result.Err[V, error](errors.New("key not found"))
+	return result.Ok[V, error](removed.Unwrap())
 }
 
 // Size implements mapx.Map.
 func (c *ConcurrentMap[K, V]) Size() int {
-	count := 0
-	c.elements.Range(func(key, value any) bool {
-		count++
-		return true
-	})
-	return count
+	return c.load().Size()
 }
 
 // Values implements mapx.Map.
 func (c *ConcurrentMap[K, V]) Values() []V {
-	var result []V
-	c.elements.Range(func(key, value any) bool {
-		result = append(result, value.(V))
-		return true
-	})
-	return result
+	return c.Snapshot().Values()
 }
 
 // FindKey implements mapx.Map.
 func (c *ConcurrentMap[K, V]) FindKey(value V) option.Option[K] {
-	var found option.Option[K] = option.None[K]()
-	c.elements.Range(func(key, val any) bool {
-		if reflect.DeepEqual(val.(V), value) {
-			found = option.Some(key.(K))
-			return false // stop iteration
+	for k, v := range c.load().All() {
+		if reflect.DeepEqual(v, value) {
+			return option.Some(k)
 		}
-		return true
-	})
-	return found
+	}
+	return option.None[K]()
 }
 
 // FindEntry implements mapx.Map.
 func (c *ConcurrentMap[K, V]) FindEntry(predicate func(K, V) bool) option.Option[mapx.Entry[K, V]] {
-	var found option.Option[mapx.Entry[K, V]] = option.None[mapx.Entry[K, V]]()
-	c.elements.Range(func(key, val any) bool {
-		k := key.(K)
-		v := val.(V)
-		if predicate(k, v) {
-			found = option.Some(mapx.Entry[K, V]{Key: k, Value: v})
-			return false // stop iteration
-		}
-		return true
-	})
-	return found
+	return c.Snapshot().FindEntry(predicate)
 }
 
 // Filter implements mapx.Map.
 func (c *ConcurrentMap[K, V]) Filter(predicate func(K, V) bool) mapx.Map[K, V] {
-	result := New[K, V]()
-	c.elements.Range(func(key, val any) bool {
-		k := key.(K)
-		v := val.(V)
-		if predicate(k, v) {
-			result.Put(k, v)
-		}
-		return true
-	})
+	result := &ConcurrentMap[K, V]{}
+	result.current.Store(c.load().Filter(predicate))
 	return result
 }
@@ -0,0 +1,131 @@
+package mapx
+
+import (
+	"encoding/json"
+
+	"github.com/gosuda/stdx/tuple"
+)
+
+// Equal reports whether a and b contain the same keys with values considered equal by eq.
+func Equal[K comparable, V any](a, b Map[K, V], eq func(V, V) bool) bool {
+	if a.Size() != b.Size() {
+		return false
+	}
+	mismatch := a.FindEntry(func(key K, value V) bool {
+		other := b.Get(key)
+		return other.IsNone() || !eq(value, other.Unwrap())
+	})
+	return mismatch.IsNone()
+}
+
+// MapDiff describes the changes that turn one map into another.
+type MapDiff[K comparable, V any] struct {
+	// Added holds entries whose key only exists in the new map.
+	Added []Entry[K, V]
+
+	// Removed holds entries whose key only exists in the old map.
+	Removed []Entry[K, V]
+
+	// Changed holds keys present in both maps with a different value, as (old, new) pairs.
+	Changed []Entry[K, tuple.Pair[V, V]]
+}
+
+// IsEmpty checks if the diff contains no changes.
+func (d MapDiff[K, V]) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Diff computes the changes that turn a into b, comparing values with eq.
+// The order of entries within each category is unspecified.
+func Diff[K comparable, V any](a, b Map[K, V], eq func(V, V) bool) MapDiff[K, V] {
+	var diff MapDiff[K, V]
+	a.ForEach(func(key K, oldValue V) {
+		newValue := b.Get(key)
+		if newValue.IsNone() {
+			diff.Removed = append(diff.Removed, Entry[K, V]{Key: key, Value: oldValue})
+		} else if !eq(oldValue, newValue.Unwrap()) {
+			diff.Changed = append(diff.Changed, Entry[K, tuple.Pair[V, V]]{
+				Key:   key,
+				Value: tuple.NewPair(oldValue, newValue.Unwrap()),
+			})
+		}
+	})
+	b.ForEach(func(key K, value V) {
+		if !a.ContainsKey(key) {
+			diff.Added = append(diff.Added, Entry[K, V]{Key: key, Value: value})
+		}
+	})
+	return diff
+}
+
+// Apply applies the diff to m and returns m.
+// Added and changed entries are stored with their new values and removed keys are deleted.
+func Apply[K comparable, V any](m Map[K, V], diff MapDiff[K, V]) Map[K, V] {
+	for _, e := range diff.Removed {
+		m.Remove(e.Key)
+	}
+	for _, e := range diff.Changed {
+		m.Put(e.Key, e.Value.Second())
+	}
+	for _, e := range diff.Added {
+		m.Put(e.Key, e.Value)
+	}
+	return m
+}
+
+type jsonEntry[K comparable, V any] struct {
+	Key   K `json:"key"`
+	Value V `json:"value"`
+}
+
+type jsonChange[K comparable, V any] struct {
+	Key K `json:"key"`
+	Old V `json:"old"`
+	New V `json:"new"`
+}
+
+type jsonDiff[K comparable, V any] struct {
+	Added   []jsonEntry[K, V]  `json:"added"`
+	Removed []jsonEntry[K, V]  `json:"removed"`
+	Changed []jsonChange[K, V] `json:"changed"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// The diff is encoded as {"added":[{"key":k,"value":v}],"removed":[...],"changed":[{"key":k,"old":o,"new":n}]}.
+func (d MapDiff[K, V]) MarshalJSON() ([]byte, error) {
+	out := jsonDiff[K, V]{
+		Added:   make([]jsonEntry[K, V], 0, len(d.Added)),
+		Removed: make([]jsonEntry[K, V], 0, len(d.Removed)),
+		Changed: make([]jsonChange[K, V], 0, len(d.Changed)),
+	}
+	for _, e := range d.Added {
+		out.Added = append(out.Added, jsonEntry[K, V]{Key: e.Key, Value: e.Value})
+	}
+	for _, e := range d.Removed {
+		out.Removed = append(out.Removed, jsonEntry[K, V]{Key: e.Key, Value: e.Value})
+	}
+	for _, e := range d.Changed {
+		out.Changed = append(out.Changed, jsonChange[K, V]{Key: e.Key, Old: e.Value.First(), New: e.Value.Second()})
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (d *MapDiff[K, V]) UnmarshalJSON(data []byte) error {
+	var in jsonDiff[K, V]
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	*d = MapDiff[K, V]{}
+	for _, e := range in.Added {
+		d.Added = append(d.Added, Entry[K, V]{Key: e.Key, Value: e.Value})
+	}
+	for _, e := range in.Removed {
+		d.Removed = append(d.Removed, Entry[K, V]{Key: e.Key, Value: e.Value})
+	}
+	for _, e := range in.Changed {
+		d.Changed = append(d.Changed, Entry[K, tuple.Pair[V, V]]{Key: e.Key, Value: tuple.NewPair(e.Old, e.New)})
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package mapx_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gosuda/stdx/mapx"
+	"github.com/gosuda/stdx/mapx/hashmap"
+)
+
+func intEq(a, b int) bool { return a == b }
+
+func TestEqual(t *testing.T) {
+	a := newSample()
+	b := newSample()
+
+	if !mapx.Equal(a, b, intEq) {
+		t.Error("Identical maps should be equal")
+	}
+
+	b.Put("four", 40)
+	if mapx.Equal(a, b, intEq) {
+		t.Error("Maps with different values should not be equal")
+	}
+
+	b.Remove("four")
+	if mapx.Equal(a, b, intEq) {
+		t.Error("Maps with different sizes should not be equal")
+	}
+
+	b.Put("five", 4)
+	if mapx.Equal(a, b, intEq) {
+		t.Error("Maps with different keys should not be equal")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	old := newSample()
+	updated := newSample()
+	updated.Remove("one")
+	updated.Put("two", 22)
+	updated.Put("five", 5)
+
+	diff := mapx.Diff(old, updated, intEq)
+
+	if len(diff.Added) != 1 || diff.Added[0].Key != "five" || diff.Added[0].Value != 5 {
+		t.Errorf("Unexpected added entries %v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].Key != "one" || diff.Removed[0].Value != 1 {
+		t.Errorf("Unexpected removed entries %v", diff.Removed)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0].Key != "two" {
+		t.Fatalf("Unexpected changed entries %v", diff.Changed)
+	}
+	if diff.Changed[0].Value.First() != 2 || diff.Changed[0].Value.Second() != 22 {
+		t.Errorf("Expected change 2 -> 22, got %v", diff.Changed[0].Value)
+	}
+
+	if !mapx.Diff(old, newSample(), intEq).IsEmpty() {
+		t.Error("Diff of equal maps should be empty")
+	}
+}
+
+func TestApply(t *testing.T) {
+	old := newSample()
+	updated := newSample()
+	updated.Remove("three")
+	updated.Put("four", 44)
+	updated.Put("six", 6)
+
+	diff := mapx.Diff(old, updated, intEq)
+	patched := mapx.Apply(old, diff)
+
+	if !mapx.Equal(patched, updated, intEq) {
+		t.Errorf("Applying diff should produce the new map, got %v", patched.Entries())
+	}
+}
+
+func TestMapDiff_JSON(t *testing.T) {
+	old := newSample()
+	updated := newSample()
+	updated.Remove("one")
+	updated.Put("two", 22)
+	updated.Put("five", 5)
+
+	data, err := json.Marshal(mapx.Diff(old, updated, intEq))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var decoded mapx.MapDiff[string, int]
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	patched := mapx.Apply(newSample(), decoded)
+	if !mapx.Equal(patched, updated, intEq) {
+		t.Errorf("Decoded diff should reproduce the new map, got %v", patched.Entries())
+	}
+
+	empty, err := json.Marshal(mapx.MapDiff[string, int]{})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(empty) != `{"added":[],"removed":[],"changed":[]}` {
+		t.Errorf("Unexpected JSON for empty diff: %s", empty)
+	}
+
+	if err := json.Unmarshal([]byte(`{"changed":[{"key":"a","old":1,"new":2}]}`), &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if len(decoded.Added) != 0 || len(decoded.Changed) != 1 || decoded.Changed[0].Value.Second() != 2 {
+		t.Errorf("Unexpected decoded diff %+v", decoded)
+	}
+
+	m := hashmap.New[string, int]()
+	m.Put("a", 1)
+	if mapx.Apply(m, decoded).Get("a").UnwrapOr(0) != 2 {
+		t.Error("Decoded change should be applied")
+	}
+}
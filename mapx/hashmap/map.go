@@ -1,7 +1,6 @@
 package hashmap
 
 import (
-	"errors"
 	"reflect"
 
 	"github.com/gosuda/stdx/mapx"
@@ -96,7 +95,7 @@ func (h *HashMap[K, V]) Remove(key K) result.Result[V, error] {
 		delete(h.elements, key)
 		return result.Ok[V, error](value)
 	}
-	return result.Err[V, error](errors.New("key not found"))
+	return result.Err[V, error](mapx.ErrKeyNotFound)
 }
 
 // Size implements mapx.Map.
@@ -127,7 +126,7 @@ func (h *HashMap[K, V]) TryRemove(key K) result.Result[V, error] {
 		delete(h.elements, key)
 		return result.Ok[V, error](value)
 	}
-	return result.Err[V, error](errors.New("key not found in map"))
+	return result.Err[V, error](mapx.ErrKeyNotFound)
 }
 
 // FindKey implements mapx.Map.
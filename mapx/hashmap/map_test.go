@@ -1,6 +1,7 @@
 package hashmap_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/gosuda/stdx/mapx"
@@ -142,8 +143,8 @@ func testMapRemove(t *testing.T, factory func() mapx.Map[string, int]) {
 
 	// Test removing non-existing key
 	result = m.Remove("key3")
-	if result.IsOk() {
-		t.Error("Remove should fail for non-existing key")
+	if result.IsOk() || !errors.Is(result.UnwrapErr(), mapx.ErrKeyNotFound) {
+		t.Error("Remove should fail with ErrKeyNotFound for non-existing key")
 	}
 
 	// Test size after removal
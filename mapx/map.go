@@ -1,10 +1,18 @@
 package mapx
 
 import (
+	"errors"
+
 	"github.com/gosuda/stdx/option"
 	"github.com/gosuda/stdx/result"
 )
 
+// ErrKeyNotFound is returned when removing a key that is not in a map.
+var ErrKeyNotFound = errors.New("key not found")
+
+// ErrReadOnly is returned or panicked with when a read-only map is mutated.
+var ErrReadOnly = errors.New("map is read-only")
+
 // Map interface defines basic operations for key-value pair storage data structures.
 type Map[K comparable, V any] interface {
 	// Put stores a key-value pair in the map. Returns Some(previousValue) if key existed, None otherwise.
@@ -0,0 +1,54 @@
+package observablemap
+
+import (
+	"github.com/gosuda/stdx/mapx"
+	"github.com/gosuda/stdx/option"
+)
+
+// EventType identifies the kind of mutation an Event describes.
+type EventType int
+
+const (
+	// EventPut is emitted when a new key is stored.
+	EventPut EventType = iota
+	// EventUpdate is emitted when the value of an existing key is replaced.
+	EventUpdate
+	// EventRemove is emitted when a key is removed.
+	EventRemove
+	// EventClear is emitted when a non-empty map is cleared.
+	EventClear
+)
+
+// String implements the fmt.Stringer interface.
+func (t EventType) String() string {
+	switch t {
+	case EventPut:
+		return "Put"
+	case EventUpdate:
+		return "Update"
+	case EventRemove:
+		return "Remove"
+	case EventClear:
+		return "Clear"
+	default:
+		return "Unknown"
+	}
+}
+
+// Event describes a single mutation of an ObservableMap.
+type Event[K comparable, V any] struct {
+	// Type is the kind of mutation.
+	Type EventType
+
+	// Key is the affected key. It is the zero value for EventClear.
+	Key K
+
+	// OldValue is the value before the mutation, None for EventPut and EventClear.
+	OldValue option.Option[V]
+
+	// NewValue is the value after the mutation, None for EventRemove and EventClear.
+	NewValue option.Option[V]
+
+	// Cleared holds the entries removed by EventClear.
+	Cleared []mapx.Entry[K, V]
+}
@@ -0,0 +1,273 @@
+// Package observablemap provides a mapx.Map decorator that notifies subscribers of every mutation.
+package observablemap
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gosuda/stdx/mapx"
+	"github.com/gosuda/stdx/option"
+	"github.com/gosuda/stdx/result"
+)
+
+var _ mapx.Map[int, string] = (*ObservableMap[int, string])(nil)
+
+// ObservableMap wraps a mapx.Map and emits an Event for every successful mutation.
+// Mutations are serialized so that every subscriber observes events in the order they were applied.
+// Reads share a read lock with each other, so any mapx.Map, including a plain hashmap, can be wrapped
+// and used from multiple goroutines.
+// The wrapped map must not be modified directly, otherwise those changes are not observed.
+//
+// SubscribeAsync starts a goroutine per subscription that runs until the subscription is cancelled,
+// so call Unsubscribe, or Close to cancel every subscription, when the map is no longer needed.
+type ObservableMap[K comparable, V any] struct {
+	inner mapx.Map[K, V]
+	mu    sync.RWMutex
+
+	subsMu sync.Mutex
+	subs   atomic.Pointer[[]*subscriber[K, V]]
+}
+
+// New wraps inner in an ObservableMap.
+func New[K comparable, V any](inner mapx.Map[K, V]) *ObservableMap[K, V] {
+	return &ObservableMap[K, V]{inner: inner}
+}
+
+// Unwrap returns the wrapped map.
+func (o *ObservableMap[K, V]) Unwrap() mapx.Map[K, V] {
+	return o.inner
+}
+
+// Subscribe registers fn to be called synchronously on the mutating goroutine for every event.
+// Events are delivered in order and the mutation does not return until fn does.
+// fn runs while the map is locked, so it must not access the map; everything it needs is in the Event.
+func (o *ObservableMap[K, V]) Subscribe(fn func(Event[K, V])) *Subscription {
+	return o.register(&Subscription{}, fn, func() {})
+}
+
+// SubscribeAsync registers fn to be called for every event on a dedicated goroutine.
+// Events are queued without bound and delivered in order, so mutations never block on fn.
+// Events still queued when the subscription is cancelled are discarded.
+// The goroutine exits only when the subscription is cancelled by Unsubscribe or Close.
+func (o *ObservableMap[K, V]) SubscribeAsync(fn func(Event[K, V])) *Subscription {
+	q := newAsyncQueue(fn)
+	return o.register(&Subscription{}, q.push, q.stop)
+}
+
+// SubscribeChan returns a channel receiving every event and its subscription.
+// Sends never block: events are dropped when the channel buffer is full and counted by Subscription.Dropped.
+// The channel is closed when the subscription is cancelled.
+func (o *ObservableMap[K, V]) SubscribeChan(buffer int) (<-chan Event[K, V], *Subscription) {
+	ch := make(chan Event[K, V], buffer)
+	var chMu sync.Mutex
+	closed := false
+
+	sub := &Subscription{}
+	o.register(sub, func(event Event[K, V]) {
+		chMu.Lock()
+		defer chMu.Unlock()
+		if closed {
+			return
+		}
+		select {
+		case ch <- event:
+		default:
+			sub.dropped.Add(1)
+		}
+	}, func() {
+		chMu.Lock()
+		defer chMu.Unlock()
+		closed = true
+		close(ch)
+	})
+	return ch, sub
+}
+
+// register adds a subscriber delivering events to deliver and returns its handle.
+// stop is called once the subscription is cancelled.
+func (o *ObservableMap[K, V]) register(sub *Subscription, deliver func(Event[K, V]), stop func()) *Subscription {
+	s := &subscriber[K, V]{sub: sub, deliver: deliver}
+	sub.cancel = func() {
+		o.unregister(s)
+		stop()
+	}
+
+	o.subsMu.Lock()
+	defer o.subsMu.Unlock()
+	var current []*subscriber[K, V]
+	if p := o.subs.Load(); p != nil {
+		current = *p
+	}
+	next := append(slices.Clip(current), s)
+	o.subs.Store(&next)
+	return sub
+}
+
+// unregister removes a subscriber.
+func (o *ObservableMap[K, V]) unregister(s *subscriber[K, V]) {
+	o.subsMu.Lock()
+	defer o.subsMu.Unlock()
+	p := o.subs.Load()
+	if p == nil {
+		return
+	}
+	next := slices.DeleteFunc(slices.Clone(*p), func(x *subscriber[K, V]) bool {
+		return x == s
+	})
+	o.subs.Store(&next)
+}
+
+// Close cancels every current subscription: asynchronous subscribers' goroutines exit
+// and subscriber channels are closed. The map itself remains usable.
+func (o *ObservableMap[K, V]) Close() {
+	p := o.subs.Load()
+	if p == nil {
+		return
+	}
+	for _, s := range *p {
+		s.sub.Unsubscribe()
+	}
+}
+
+// hasSubscribers reports whether any subscriber is registered.
+func (o *ObservableMap[K, V]) hasSubscribers() bool {
+	p := o.subs.Load()
+	return p != nil && len(*p) > 0
+}
+
+// emit delivers an event to every active subscriber. The caller must hold o.mu.
+func (o *ObservableMap[K, V]) emit(event Event[K, V]) {
+	p := o.subs.Load()
+	if p == nil {
+		return
+	}
+	for _, s := range *p {
+		if s.sub.Active() {
+			s.deliver(event)
+		}
+	}
+}
+
+// Put implements mapx.Map. Emits EventPut for new keys and EventUpdate for existing keys.
+func (o *ObservableMap[K, V]) Put(key K, value V) option.Option[V] {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	previous := o.inner.Put(key, value)
+	event := Event[K, V]{Type: EventPut, Key: key, OldValue: previous, NewValue: option.Some(value)}
+	if previous.IsSome() {
+		event.Type = EventUpdate
+	}
+	o.emit(event)
+	return previous
+}
+
+// Remove implements mapx.Map. Emits EventRemove if the key existed.
+func (o *ObservableMap[K, V]) Remove(key K) result.Result[V, error] {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	removed := o.inner.Remove(key)
+	if removed.IsOk() {
+		o.emit(Event[K, V]{Type: EventRemove, Key: key, OldValue: removed.Ok(), NewValue: option.None[V]()})
+	}
+	return removed
+}
+
+// Clear implements mapx.Map. Emits EventClear with the removed entries if the map was not empty.
+func (o *ObservableMap[K, V]) Clear() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if !o.hasSubscribers() {
+		o.inner.Clear()
+		return
+	}
+	cleared := o.inner.Entries()
+	o.inner.Clear()
+	if len(cleared) > 0 {
+		o.emit(Event[K, V]{Type: EventClear, OldValue: option.None[V](), NewValue: option.None[V](), Cleared: cleared})
+	}
+}
+
+// Get implements mapx.Map.
+func (o *ObservableMap[K, V]) Get(key K) option.Option[V] {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.inner.Get(key)
+}
+
+// ContainsKey implements mapx.Map.
+func (o *ObservableMap[K, V]) ContainsKey(key K) bool {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.inner.ContainsKey(key)
+}
+
+// ContainsValue implements mapx.Map.
+func (o *ObservableMap[K, V]) ContainsValue(value V) bool {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.inner.ContainsValue(value)
+}
+
+// Size implements mapx.Map.
+func (o *ObservableMap[K, V]) Size() int {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.inner.Size()
+}
+
+// IsEmpty implements mapx.Map.
+func (o *ObservableMap[K, V]) IsEmpty() bool {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.inner.IsEmpty()
+}
+
+// Keys implements mapx.Map.
+func (o *ObservableMap[K, V]) Keys() []K {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.inner.Keys()
+}
+
+// Values implements mapx.Map.
+func (o *ObservableMap[K, V]) Values() []V {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.inner.Values()
+}
+
+// Entries implements mapx.Map.
+func (o *ObservableMap[K, V]) Entries() []mapx.Entry[K, V] {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.inner.Entries()
+}
+
+// ForEach implements mapx.Map. fn runs under the read lock, so it must not modify the map.
+func (o *ObservableMap[K, V]) ForEach(fn func(key K, value V)) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	o.inner.ForEach(fn)
+}
+
+// FindKey implements mapx.Map.
+func (o *ObservableMap[K, V]) FindKey(value V) option.Option[K] {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.inner.FindKey(value)
+}
+
+// FindEntry implements mapx.Map.
+func (o *ObservableMap[K, V]) FindEntry(predicate func(K, V) bool) option.Option[mapx.Entry[K, V]] {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.inner.FindEntry(predicate)
+}
+
+// Filter implements mapx.Map. The returned map is produced by the wrapped map and is not observed.
+func (o *ObservableMap[K, V]) Filter(predicate func(K, V) bool) mapx.Map[K, V] {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.inner.Filter(predicate)
+}
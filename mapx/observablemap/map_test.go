@@ -0,0 +1,226 @@
+package observablemap_test
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gosuda/stdx/mapx/concurrentmap"
+	"github.com/gosuda/stdx/mapx/hashmap"
+	"github.com/gosuda/stdx/mapx/observablemap"
+)
+
+func TestObservableMap_Events(t *testing.T) {
+	m := observablemap.New[string, int](hashmap.New[string, int]())
+
+	var events []observablemap.Event[string, int]
+	m.Subscribe(func(e observablemap.Event[string, int]) {
+		events = append(events, e)
+	})
+
+	m.Put("a", 1)
+	m.Put("a", 2)
+	m.Remove("a")
+	m.Remove("missing")
+	m.Put("b", 3)
+	m.Clear()
+	m.Clear()
+
+	expected := []observablemap.EventType{
+		observablemap.EventPut,
+		observablemap.EventUpdate,
+		observablemap.EventRemove,
+		observablemap.EventPut,
+		observablemap.EventClear,
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events, got %d: %v", len(expected), len(events), events)
+	}
+	for i, typ := range expected {
+		if events[i].Type != typ {
+			t.Errorf("Event %d: expected %v, got %v", i, typ, events[i].Type)
+		}
+	}
+
+	if events[0].OldValue.IsSome() || events[0].NewValue.UnwrapOr(0) != 1 {
+		t.Errorf("Unexpected Put event %+v", events[0])
+	}
+	if events[1].OldValue.UnwrapOr(0) != 1 || events[1].NewValue.UnwrapOr(0) != 2 {
+		t.Errorf("Unexpected Update event %+v", events[1])
+	}
+	if events[2].Key != "a" || events[2].OldValue.UnwrapOr(0) != 2 || events[2].NewValue.IsSome() {
+		t.Errorf("Unexpected Remove event %+v", events[2])
+	}
+	if len(events[4].Cleared) != 1 || events[4].Cleared[0].Key != "b" {
+		t.Errorf("Unexpected Clear event %+v", events[4])
+	}
+}
+
+func TestObservableMap_Forwarding(t *testing.T) {
+	inner := hashmap.New[string, int]()
+	m := observablemap.New[string, int](inner)
+	m.Put("a", 1)
+	m.Put("b", 2)
+
+	if m.Unwrap() != inner || inner.Size() != 2 {
+		t.Error("Mutations should be applied to the wrapped map")
+	}
+	if m.Get("a").UnwrapOr(0) != 1 || !m.ContainsKey("b") || !m.ContainsValue(2) {
+		t.Error("Reads should be forwarded to the wrapped map")
+	}
+	if m.Size() != 2 || m.IsEmpty() || len(m.Keys()) != 2 || len(m.Values()) != 2 || len(m.Entries()) != 2 {
+		t.Error("Size and listings should be forwarded to the wrapped map")
+	}
+	if m.FindKey(2).UnwrapOr("") != "b" {
+		t.Error("FindKey should be forwarded to the wrapped map")
+	}
+	if m.FindEntry(func(k string, v int) bool { return v == 1 }).IsNone() {
+		t.Error("FindEntry should be forwarded to the wrapped map")
+	}
+	if m.Filter(func(k string, v int) bool { return v > 1 }).Size() != 1 {
+		t.Error("Filter should be forwarded to the wrapped map")
+	}
+
+	count := 0
+	m.ForEach(func(k string, v int) { count++ })
+	if count != 2 {
+		t.Errorf("Expected 2 entries, got %d", count)
+	}
+}
+
+func TestObservableMap_ConcurrentOrdering(t *testing.T) {
+	m := observablemap.New[int, int](concurrentmap.New[int, int]())
+
+	// Sync subscribers run while mutations are serialized, so no extra locking is needed.
+	last := map[int]int{}
+	count := 0
+	m.Subscribe(func(e observablemap.Event[int, int]) {
+		last[e.Key] = e.NewValue.UnwrapOr(0)
+		count++
+	})
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				m.Put(i%5, g*1000+i)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if count != 800 {
+		t.Errorf("Expected 800 events, got %d", count)
+	}
+	// The last event for every key must describe the final state of the map.
+	for k, v := range last {
+		if m.Get(k).UnwrapOr(-1) != v {
+			t.Errorf("Last event for key %d (%d) does not match map value %d", k, v, m.Get(k).UnwrapOr(-1))
+		}
+	}
+}
+
+func TestObservableMap_Async(t *testing.T) {
+	m := observablemap.New[int, int](hashmap.New[int, int]())
+
+	received := make(chan int, 100)
+	block := make(chan struct{})
+	sub := m.SubscribeAsync(func(e observablemap.Event[int, int]) {
+		<-block
+		received <- e.Key
+	})
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 50; i++ {
+			m.Put(i, i)
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Mutations should not block on an async subscriber")
+	}
+
+	close(block)
+	for i := 0; i < 50; i++ {
+		select {
+		case k := <-received:
+			if k != i {
+				t.Fatalf("Expected event for key %d, got %d", i, k)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for async events")
+		}
+	}
+	sub.Unsubscribe()
+}
+
+func TestObservableMap_ConcurrentReadsWithPlainMap(t *testing.T) {
+	m := observablemap.New[int, int](hashmap.New[int, int]())
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				m.Put(i%10, g)
+				m.Remove(i % 7)
+			}
+		}(g)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				m.Get(i % 10)
+				m.Size()
+				m.Keys()
+				m.ForEach(func(int, int) {})
+			}
+		}()
+	}
+	wg.Wait()
+
+	if m.Size() > 10 {
+		t.Errorf("Expected at most 10 keys, got %d", m.Size())
+	}
+}
+
+func TestObservableMap_Close(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	m := observablemap.New[int, int](hashmap.New[int, int]())
+	subs := make([]*observablemap.Subscription, 0, 10)
+	for i := 0; i < 10; i++ {
+		subs = append(subs, m.SubscribeAsync(func(observablemap.Event[int, int]) {}))
+	}
+	ch, chSub := m.SubscribeChan(1)
+	m.Put(1, 1)
+
+	m.Close()
+	for _, sub := range append(subs, chSub) {
+		if sub.Active() {
+			t.Error("Close should cancel every subscription")
+		}
+	}
+	for range ch {
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > before {
+		t.Errorf("Expected async subscriber goroutines to exit, %d goroutines left over %d", n, before)
+	}
+
+	m.Put(2, 2)
+	if m.Size() != 2 {
+		t.Error("The map should remain usable after Close")
+	}
+}
@@ -0,0 +1,97 @@
+package observablemap
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Subscription is a handle to a registered subscriber.
+type Subscription struct {
+	once    sync.Once
+	closed  atomic.Bool
+	dropped atomic.Uint64
+	cancel  func()
+}
+
+// Unsubscribe stops event delivery to the subscriber. It is safe to call more than once
+// and from within the subscriber itself.
+func (s *Subscription) Unsubscribe() {
+	s.once.Do(func() {
+		s.closed.Store(true)
+		s.cancel()
+	})
+}
+
+// Active reports whether the subscription still receives events.
+func (s *Subscription) Active() bool {
+	return !s.closed.Load()
+}
+
+// Dropped returns the number of events dropped because a channel subscriber's buffer was full.
+func (s *Subscription) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// subscriber is a registered event consumer.
+type subscriber[K comparable, V any] struct {
+	sub     *Subscription
+	deliver func(Event[K, V])
+}
+
+// asyncQueue delivers events in order on a dedicated goroutine without blocking the producer.
+type asyncQueue[K comparable, V any] struct {
+	mu      sync.Mutex
+	pending []Event[K, V]
+	wake    chan struct{}
+	done    chan struct{}
+}
+
+func newAsyncQueue[K comparable, V any](fn func(Event[K, V])) *asyncQueue[K, V] {
+	q := &asyncQueue[K, V]{
+		wake: make(chan struct{}, 1),
+		done: make(chan struct{}),
+	}
+	go q.run(fn)
+	return q
+}
+
+// push appends an event to the queue.
+func (q *asyncQueue[K, V]) push(event Event[K, V]) {
+	q.mu.Lock()
+	q.pending = append(q.pending, event)
+	q.mu.Unlock()
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// stop terminates the delivery goroutine, discarding undelivered events.
+func (q *asyncQueue[K, V]) stop() {
+	close(q.done)
+}
+
+func (q *asyncQueue[K, V]) run(fn func(Event[K, V])) {
+	for {
+		select {
+		case <-q.done:
+			return
+		case <-q.wake:
+		}
+
+		q.mu.Lock()
+		batch := q.pending
+		q.pending = nil
+		q.mu.Unlock()
+
+		for _, event := range batch {
+			select {
+			case <-q.done:
+				return
+			default:
+			}
+			fn(event)
+		}
+	}
+}
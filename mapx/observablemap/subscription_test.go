@@ -0,0 +1,69 @@
+package observablemap_test
+
+import (
+	"testing"
+
+	"github.com/gosuda/stdx/mapx/hashmap"
+	"github.com/gosuda/stdx/mapx/observablemap"
+)
+
+func TestSubscription_Unsubscribe(t *testing.T) {
+	m := observablemap.New[string, int](hashmap.New[string, int]())
+
+	calls := 0
+	var sub *observablemap.Subscription
+	sub = m.Subscribe(func(e observablemap.Event[string, int]) {
+		calls++
+		sub.Unsubscribe()
+	})
+
+	m.Put("a", 1)
+	m.Put("b", 2)
+	sub.Unsubscribe()
+
+	if calls != 1 {
+		t.Errorf("Expected 1 call before unsubscribing, got %d", calls)
+	}
+	if sub.Active() {
+		t.Error("Subscription should not be active after Unsubscribe")
+	}
+}
+
+func TestSubscription_Chan(t *testing.T) {
+	m := observablemap.New[string, int](hashmap.New[string, int]())
+	ch, sub := m.SubscribeChan(2)
+
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("c", 3)
+
+	if sub.Dropped() != 1 {
+		t.Errorf("Expected 1 dropped event, got %d", sub.Dropped())
+	}
+	if e := <-ch; e.Key != "a" || e.Type != observablemap.EventPut {
+		t.Errorf("Unexpected first event %+v", e)
+	}
+	if e := <-ch; e.Key != "b" {
+		t.Errorf("Unexpected second event %+v", e)
+	}
+
+	sub.Unsubscribe()
+	m.Put("d", 4)
+	if _, ok := <-ch; ok {
+		t.Error("Channel should be closed after Unsubscribe")
+	}
+}
+
+func TestEventType_String(t *testing.T) {
+	names := map[observablemap.EventType]string{
+		observablemap.EventPut:    "Put",
+		observablemap.EventUpdate: "Update",
+		observablemap.EventRemove: "Remove",
+		observablemap.EventClear:  "Clear",
+	}
+	for typ, name := range names {
+		if typ.String() != name {
+			t.Errorf("Expected %s, got %s", name, typ.String())
+		}
+	}
+}
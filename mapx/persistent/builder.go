@@ -0,0 +1,73 @@
+package persistent
+
+import (
+	"github.com/gosuda/stdx/option"
+)
+
+// Builder is a transient, mutable version of Map used for bulk loads.
+// Nodes created by the builder are updated in place until Map is called,
+// which makes bulk insertion much cheaper than a series of Map.Put calls.
+// A Builder is not safe for concurrent use.
+type Builder[K comparable, V any] struct {
+	root  *node[K, V]
+	size  int
+	hash  func(K) uint64
+	owner *owner
+}
+
+// NewBuilder creates a Builder for an empty map.
+func NewBuilder[K comparable, V any]() *Builder[K, V] {
+	return New[K, V]().Builder()
+}
+
+// Put stores a key-value pair. Returns Some(previousValue) if key existed, None otherwise.
+func (b *Builder[K, V]) Put(key K, value V) option.Option[V] {
+	h := b.hash(key)
+	previous, exists := b.root.get(0, h, key)
+	root, added := b.root.put(b.owner, 0, entry[K, V]{hash: h, key: key, value: value})
+	b.root = root
+	if added {
+		b.size++
+	}
+	if !exists {
+		return option.None[V]()
+	}
+	return option.Some(previous)
+}
+
+// Remove removes key. Returns Some(removedValue) if key existed, None otherwise.
+func (b *Builder[K, V]) Remove(key K) option.Option[V] {
+	root, old, removed := b.root.remove(b.owner, 0, b.hash(key), key)
+	if !removed {
+		return option.None[V]()
+	}
+	b.root = root
+	b.size--
+	return option.Some(old)
+}
+
+// Get returns Some(value) if key exists, None otherwise.
+func (b *Builder[K, V]) Get(key K) option.Option[V] {
+	if value, exists := b.root.get(0, b.hash(key), key); exists {
+		return option.Some(value)
+	}
+	return option.None[V]()
+}
+
+// ContainsKey checks if the key exists in the builder.
+func (b *Builder[K, V]) ContainsKey(key K) bool {
+	_, exists := b.root.get(0, b.hash(key), key)
+	return exists
+}
+
+// Size returns the number of entries in the builder.
+func (b *Builder[K, V]) Size() int {
+	return b.size
+}
+
+// Map returns an immutable Map with the current contents of the builder.
+// The builder remains usable; later changes do not affect the returned map.
+func (b *Builder[K, V]) Map() *Map[K, V] {
+	b.owner = &owner{}
+	return &Map[K, V]{root: b.root, size: b.size, hash: b.hash}
+}
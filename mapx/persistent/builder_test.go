@@ -0,0 +1,59 @@
+package persistent_test
+
+import (
+	"testing"
+
+	"github.com/gosuda/stdx/mapx/persistent"
+)
+
+func TestBuilder_BulkLoad(t *testing.T) {
+	b := persistent.NewBuilder[int, int]()
+	for i := 0; i < 1000; i++ {
+		if b.Put(i, i).IsSome() {
+			t.Fatalf("Put of new key %d should return None", i)
+		}
+	}
+	if b.Put(7, 70).UnwrapOr(-1) != 7 {
+		t.Error("Put of existing key should return previous value")
+	}
+	if b.Remove(8).UnwrapOr(-1) != 8 {
+		t.Error("Remove should return removed value")
+	}
+	if b.Remove(8).IsSome() {
+		t.Error("Removing missing key should return None")
+	}
+
+	m := b.Map()
+	if m.Size() != 999 {
+		t.Errorf("Expected size 999, got %d", m.Size())
+	}
+	if m.Get(7).UnwrapOr(-1) != 70 || m.ContainsKey(8) {
+		t.Error("Map should reflect builder changes")
+	}
+}
+
+func TestBuilder_IsolatedFromMaps(t *testing.T) {
+	base := persistent.New[string, int]().Set("a", 1).Set("b", 2)
+
+	b := base.Builder()
+	b.Put("a", 10)
+	b.Put("c", 3)
+	if base.Get("a").Unwrap() != 1 || base.ContainsKey("c") {
+		t.Error("Builder should not modify the map it was created from")
+	}
+
+	first := b.Map()
+	b.Put("a", 100)
+	b.Remove("b")
+	second := b.Map()
+
+	if first.Get("a").Unwrap() != 10 || !first.ContainsKey("b") {
+		t.Error("Changes after Map should not affect previously returned maps")
+	}
+	if second.Get("a").Unwrap() != 100 || second.ContainsKey("b") {
+		t.Error("Second map should reflect later builder changes")
+	}
+	if b.Size() != 2 || !b.ContainsKey("c") || b.Get("b").IsSome() {
+		t.Error("Builder should reflect its own state")
+	}
+}
@@ -0,0 +1,157 @@
+// Package persistent provides an immutable map based on a hash array mapped trie (HAMT).
+// Every update returns a new version of the map that shares unchanged structure with the previous one,
+// so forking a map is O(1) and each update only copies O(log32 n) nodes.
+package persistent
+
+import (
+	"hash/maphash"
+	"iter"
+
+	"github.com/gosuda/stdx/option"
+)
+
+// Map is an immutable hash map. The zero value is not usable; create maps with New.
+// A Map is safe for concurrent use by multiple goroutines since it is never modified.
+type Map[K comparable, V any] struct {
+	root *node[K, V]
+	size int
+	hash func(K) uint64
+}
+
+// New creates an empty Map.
+func New[K comparable, V any]() *Map[K, V] {
+	seed := maphash.MakeSeed()
+	return &Map[K, V]{
+		root: &node[K, V]{},
+		hash: func(key K) uint64 {
+			return maphash.Comparable(seed, key)
+		},
+	}
+}
+
+// Put returns a new map with key associated to value, along with Some(previousValue) if key existed, None otherwise.
+func (m *Map[K, V]) Put(key K, value V) (*Map[K, V], option.Option[V]) {
+	h := m.hash(key)
+	previous, exists := m.root.get(0, h, key)
+	root, added := m.root.put(nil, 0, entry[K, V]{hash: h, key: key, value: value})
+	next := &Map[K, V]{root: root, size: m.size, hash: m.hash}
+	if added {
+		next.size++
+	}
+	if !exists {
+		return next, option.None[V]()
+	}
+	return next, option.Some(previous)
+}
+
+// Set returns a new map with key associated to value.
+func (m *Map[K, V]) Set(key K, value V) *Map[K, V] {
+	next, _ := m.Put(key, value)
+	return next
+}
+
+// Remove returns a new map without key, along with Some(removedValue) if key existed, None otherwise.
+// If key does not exist, the receiver itself is returned.
+func (m *Map[K, V]) Remove(key K) (*Map[K, V], option.Option[V]) {
+	root, old, removed := m.root.remove(nil, 0, m.hash(key), key)
+	if !removed {
+		return m, option.None[V]()
+	}
+	return &Map[K, V]{root: root, size: m.size - 1, hash: m.hash}, option.Some(old)
+}
+
+// Delete returns a new map without key.
+func (m *Map[K, V]) Delete(key K) *Map[K, V] {
+	next, _ := m.Remove(key)
+	return next
+}
+
+// Get returns Some(value) if key exists, None otherwise.
+func (m *Map[K, V]) Get(key K) option.Option[V] {
+	if value, exists := m.root.get(0, m.hash(key), key); exists {
+		return option.Some(value)
+	}
+	return option.None[V]()
+}
+
+// ContainsKey checks if the key exists in the map.
+func (m *Map[K, V]) ContainsKey(key K) bool {
+	_, exists := m.root.get(0, m.hash(key), key)
+	return exists
+}
+
+// Size returns the number of entries in the map.
+func (m *Map[K, V]) Size() int {
+	return m.size
+}
+
+// IsEmpty checks if the map is empty.
+func (m *Map[K, V]) IsEmpty() bool {
+	return m.size == 0
+}
+
+// Clear returns an empty map that hashes keys the same way as the receiver.
+func (m *Map[K, V]) Clear() *Map[K, V] {
+	return &Map[K, V]{root: &node[K, V]{}, hash: m.hash}
+}
+
+// All returns an iterator over all key-value pairs in the map.
+// The iteration order is unspecified but stable for a given map.
+func (m *Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.root.all(yield)
+	}
+}
+
+// Keys returns an iterator over all keys in the map.
+func (m *Map[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		m.root.all(func(key K, _ V) bool {
+			return yield(key)
+		})
+	}
+}
+
+// Values returns an iterator over all values in the map.
+func (m *Map[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		m.root.all(func(_ K, value V) bool {
+			return yield(value)
+		})
+	}
+}
+
+// ForEach executes a function for every key-value pair in the map.
+func (m *Map[K, V]) ForEach(fn func(key K, value V)) {
+	m.root.all(func(key K, value V) bool {
+		fn(key, value)
+		return true
+	})
+}
+
+// Filter returns a new map containing only entries that match the predicate.
+func (m *Map[K, V]) Filter(predicate func(K, V) bool) *Map[K, V] {
+	b := m.Clear().Builder()
+	m.ForEach(func(key K, value V) {
+		if predicate(key, value) {
+			b.Put(key, value)
+		}
+	})
+	return b.Map()
+}
+
+// Builder returns a transient builder initialized with the contents of the map.
+// The map itself is not affected by changes made through the builder.
+func (m *Map[K, V]) Builder() *Builder[K, V] {
+	return &Builder[K, V]{
+		root:  m.root,
+		size:  m.size,
+		hash:  m.hash,
+		owner: &owner{},
+	}
+}
+
+// ReadOnly returns a read-only view of the map implementing mapx.Map.
+func (m *Map[K, V]) ReadOnly() *ReadOnlyMap[K, V] {
+	return &ReadOnlyMap[K, V]{m: m}
+}
@@ -0,0 +1,190 @@
+package persistent
+
+import (
+	"fmt"
+	"testing"
+)
+
+// newCollidingMap creates a Map whose keys all share the same hash,
+// which forces every entry into a single collision node.
+func newCollidingMap[K comparable, V any]() *Map[K, V] {
+	m := New[K, V]()
+	m.hash = func(K) uint64 { return 42 }
+	return m
+}
+
+func TestMap_PutGet(t *testing.T) {
+	m := New[string, int]()
+
+	m1, prev := m.Put("a", 1)
+	if prev.IsSome() {
+		t.Error("Put of new key should return None")
+	}
+	if m1.Get("a").UnwrapOr(0) != 1 {
+		t.Error("Expected a=1 after Put")
+	}
+	if m.ContainsKey("a") {
+		t.Error("Original map should not be modified by Put")
+	}
+
+	m2, prev := m1.Put("a", 2)
+	if prev.UnwrapOr(0) != 1 {
+		t.Errorf("Expected previous value 1, got %v", prev)
+	}
+	if m2.Size() != 1 {
+		t.Errorf("Expected size 1 after replacing, got %d", m2.Size())
+	}
+	if m1.Get("a").Unwrap() != 1 || m2.Get("a").Unwrap() != 2 {
+		t.Error("Versions should keep their own values")
+	}
+}
+
+func TestMap_Remove(t *testing.T) {
+	m := New[int, int]()
+	for i := 0; i < 100; i++ {
+		m = m.Set(i, i*i)
+	}
+
+	m2, removed := m.Remove(50)
+	if removed.UnwrapOr(-1) != 2500 {
+		t.Errorf("Expected removed value 2500, got %v", removed)
+	}
+	if m2.ContainsKey(50) || m2.Size() != 99 {
+		t.Error("Key should be removed from new version")
+	}
+	if !m.ContainsKey(50) || m.Size() != 100 {
+		t.Error("Original map should not be modified by Remove")
+	}
+
+	m3, removed := m2.Remove(50)
+	if removed.IsSome() {
+		t.Error("Removing missing key should return None")
+	}
+	if m3 != m2 {
+		t.Error("Removing missing key should return the same map")
+	}
+}
+
+func TestMap_Large(t *testing.T) {
+	const n = 10000
+	m := New[string, int]()
+	for i := 0; i < n; i++ {
+		m = m.Set(fmt.Sprint(i), i)
+	}
+	if m.Size() != n {
+		t.Fatalf("Expected size %d, got %d", n, m.Size())
+	}
+	for i := 0; i < n; i++ {
+		if m.Get(fmt.Sprint(i)).UnwrapOr(-1) != i {
+			t.Fatalf("Missing key %d", i)
+		}
+	}
+
+	count := 0
+	for range m.All() {
+		count++
+	}
+	if count != n {
+		t.Errorf("Expected %d entries from All, got %d", n, count)
+	}
+
+	for i := 0; i < n; i += 2 {
+		m = m.Delete(fmt.Sprint(i))
+	}
+	if m.Size() != n/2 {
+		t.Fatalf("Expected size %d, got %d", n/2, m.Size())
+	}
+	for i := 0; i < n; i++ {
+		if m.ContainsKey(fmt.Sprint(i)) != (i%2 == 1) {
+			t.Fatalf("Unexpected presence of key %d", i)
+		}
+	}
+}
+
+func TestMap_Collisions(t *testing.T) {
+	m := newCollidingMap[int, string]()
+	for i := 0; i < 10; i++ {
+		m = m.Set(i, fmt.Sprint(i))
+	}
+	if m.Size() != 10 {
+		t.Fatalf("Expected size 10, got %d", m.Size())
+	}
+	for i := 0; i < 10; i++ {
+		if m.Get(i).UnwrapOr("") != fmt.Sprint(i) {
+			t.Errorf("Expected %d to be found in collision node", i)
+		}
+	}
+
+	for i := 0; i < 9; i++ {
+		m = m.Delete(i)
+	}
+	if m.Size() != 1 || m.Get(9).UnwrapOr("") != "9" {
+		t.Error("Expected only key 9 to remain")
+	}
+	if len(m.root.entries) != 1 || len(m.root.children) != 0 {
+		t.Error("Single remaining entry should be inlined into the root")
+	}
+}
+
+func TestMap_Iterators(t *testing.T) {
+	m := New[int, int]()
+	for i := 0; i < 50; i++ {
+		m = m.Set(i, i*2)
+	}
+
+	keys := 0
+	for k := range m.Keys() {
+		keys += k
+	}
+	if keys != 1225 {
+		t.Errorf("Expected key sum 1225, got %d", keys)
+	}
+
+	values := 0
+	for v := range m.Values() {
+		values += v
+	}
+	if values != 2450 {
+		t.Errorf("Expected value sum 2450, got %d", values)
+	}
+
+	seen := 0
+	for range m.All() {
+		seen++
+		if seen == 5 {
+			break
+		}
+	}
+	if seen != 5 {
+		t.Errorf("Iteration should stop early, saw %d", seen)
+	}
+}
+
+func TestMap_Filter(t *testing.T) {
+	m := New[int, int]()
+	for i := 0; i < 20; i++ {
+		m = m.Set(i, i)
+	}
+
+	even := m.Filter(func(k, v int) bool { return k%2 == 0 })
+	if even.Size() != 10 {
+		t.Errorf("Expected 10 entries, got %d", even.Size())
+	}
+	if even.ContainsKey(3) {
+		t.Error("Filtered map should not contain odd keys")
+	}
+	if m.Size() != 20 {
+		t.Error("Original map should not be modified by Filter")
+	}
+}
+
+func TestMap_Clear(t *testing.T) {
+	m := New[int, int]().Set(1, 1).Set(2, 2)
+	empty := m.Clear()
+	if !empty.IsEmpty() {
+		t.Error("Cleared map should be empty")
+	}
+	if m.Size() != 2 {
+		t.Error("Original map should not be modified by Clear")
+	}
+}
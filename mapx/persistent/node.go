@@ -0,0 +1,237 @@
+package persistent
+
+import (
+	"math/bits"
+	"slices"
+)
+
+const (
+	bitsPerLevel = 5
+	levelMask    = 1<<bitsPerLevel - 1
+	hashBits     = 64
+)
+
+// owner identifies the transient builder allowed to mutate a node in place.
+// It must not be zero-sized so that every allocation has a distinct address.
+type owner struct {
+	_ byte
+}
+
+// entry is a key-value pair stored in a trie node along with the key's hash.
+type entry[K comparable, V any] struct {
+	hash  uint64
+	key   K
+	value V
+}
+
+// node is a compressed hash array mapped trie node.
+// dataMap marks the slots holding entries and nodeMap marks the slots holding children.
+// Once the hash bits are exhausted a node becomes a collision node that stores its entries linearly.
+type node[K comparable, V any] struct {
+	dataMap  uint32
+	nodeMap  uint32
+	entries  []entry[K, V]
+	children []*node[K, V]
+	owner    *owner
+}
+
+// bitpos returns the bitmap bit for the hash fragment at the given shift.
+func bitpos(hash uint64, shift uint) uint32 {
+	return 1 << ((hash >> shift) & levelMask)
+}
+
+// index returns the position of bit within the compacted array described by bitmap.
+func index(bitmap, bit uint32) int {
+	return bits.OnesCount32(bitmap & (bit - 1))
+}
+
+// editable returns n itself if it is owned by o, otherwise a copy owned by o.
+func (n *node[K, V]) editable(o *owner) *node[K, V] {
+	if o != nil && n.owner == o {
+		return n
+	}
+	return &node[K, V]{
+		dataMap:  n.dataMap,
+		nodeMap:  n.nodeMap,
+		entries:  slices.Clone(n.entries),
+		children: slices.Clone(n.children),
+		owner:    o,
+	}
+}
+
+// get looks up key with the given hash in the subtrie rooted at n.
+func (n *node[K, V]) get(shift uint, hash uint64, key K) (V, bool) {
+	for {
+		if shift >= hashBits {
+			for _, e := range n.entries {
+				if e.key == key {
+					return e.value, true
+				}
+			}
+			var zero V
+			return zero, false
+		}
+
+		bit := bitpos(hash, shift)
+		if n.dataMap&bit != 0 {
+			e := n.entries[index(n.dataMap, bit)]
+			if e.key == key {
+				return e.value, true
+			}
+			var zero V
+			return zero, false
+		}
+		if n.nodeMap&bit == 0 {
+			var zero V
+			return zero, false
+		}
+		n = n.children[index(n.nodeMap, bit)]
+		shift += bitsPerLevel
+	}
+}
+
+// put inserts or replaces e in the subtrie rooted at n and returns the new subtrie.
+// The returned flag reports whether the key was newly added.
+func (n *node[K, V]) put(o *owner, shift uint, e entry[K, V]) (*node[K, V], bool) {
+	if shift >= hashBits {
+		for i := range n.entries {
+			if n.entries[i].key == e.key {
+				m := n.editable(o)
+				m.entries[i] = e
+				return m, false
+			}
+		}
+		m := n.editable(o)
+		m.entries = append(m.entries, e)
+		return m, true
+	}
+
+	bit := bitpos(e.hash, shift)
+	if n.dataMap&bit != 0 {
+		idx := index(n.dataMap, bit)
+		current := n.entries[idx]
+		if current.key == e.key {
+			m := n.editable(o)
+			m.entries[idx] = e
+			return m, false
+		}
+
+		// Two different keys share this slot, so push both one level down.
+		child := newPair(o, shift+bitsPerLevel, current, e)
+		m := n.editable(o)
+		m.dataMap ^= bit
+		m.nodeMap |= bit
+		m.entries = slices.Delete(m.entries, idx, idx+1)
+		m.children = slices.Insert(m.children, index(m.nodeMap, bit), child)
+		return m, true
+	}
+
+	if n.nodeMap&bit != 0 {
+		idx := index(n.nodeMap, bit)
+		child, added := n.children[idx].put(o, shift+bitsPerLevel, e)
+		if child == n.children[idx] {
+			return n, added
+		}
+		m := n.editable(o)
+		m.children[idx] = child
+		return m, added
+	}
+
+	m := n.editable(o)
+	m.dataMap |= bit
+	m.entries = slices.Insert(m.entries, index(m.dataMap, bit), e)
+	return m, true
+}
+
+// remove deletes key from the subtrie rooted at n and returns the new subtrie.
+// If the key is not present, n is returned unchanged along with false.
+func (n *node[K, V]) remove(o *owner, shift uint, hash uint64, key K) (*node[K, V], V, bool) {
+	var zero V
+
+	if shift >= hashBits {
+		for i := range n.entries {
+			if n.entries[i].key == key {
+				old := n.entries[i].value
+				m := n.editable(o)
+				m.entries = slices.Delete(m.entries, i, i+1)
+				return m, old, true
+			}
+		}
+		return n, zero, false
+	}
+
+	bit := bitpos(hash, shift)
+	if n.dataMap&bit != 0 {
+		idx := index(n.dataMap, bit)
+		if n.entries[idx].key != key {
+			return n, zero, false
+		}
+		old := n.entries[idx].value
+		m := n.editable(o)
+		m.dataMap ^= bit
+		m.entries = slices.Delete(m.entries, idx, idx+1)
+		return m, old, true
+	}
+
+	if n.nodeMap&bit != 0 {
+		idx := index(n.nodeMap, bit)
+		child, old, removed := n.children[idx].remove(o, shift+bitsPerLevel, hash, key)
+		if !removed {
+			return n, zero, false
+		}
+
+		m := n.editable(o)
+		if len(child.children) == 0 && len(child.entries) == 1 {
+			// Inline a child that is left with a single entry.
+			m.nodeMap ^= bit
+			m.children = slices.Delete(m.children, idx, idx+1)
+			m.dataMap |= bit
+			m.entries = slices.Insert(m.entries, index(m.dataMap, bit), child.entries[0])
+		} else {
+			m.children[idx] = child
+		}
+		return m, old, true
+	}
+
+	return n, zero, false
+}
+
+// all yields every entry in the subtrie rooted at n until yield returns false.
+func (n *node[K, V]) all(yield func(K, V) bool) bool {
+	for _, e := range n.entries {
+		if !yield(e.key, e.value) {
+			return false
+		}
+	}
+	for _, child := range n.children {
+		if !child.all(yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// newPair creates the subtrie holding two entries whose hashes agree below shift.
+func newPair[K comparable, V any](o *owner, shift uint, a, b entry[K, V]) *node[K, V] {
+	if shift >= hashBits {
+		return &node[K, V]{entries: []entry[K, V]{a, b}, owner: o}
+	}
+
+	bitA := bitpos(a.hash, shift)
+	bitB := bitpos(b.hash, shift)
+	if bitA == bitB {
+		return &node[K, V]{
+			nodeMap:  bitA,
+			children: []*node[K, V]{newPair(o, shift+bitsPerLevel, a, b)},
+			owner:    o,
+		}
+	}
+	if bitA > bitB {
+		a, b = b, a
+	}
+	return &node[K, V]{
+		dataMap: bitA | bitB,
+		entries: []entry[K, V]{a, b},
+		owner:   o,
+	}
+}
@@ -0,0 +1,119 @@
+package persistent
+
+import (
+	"reflect"
+
+	"github.com/gosuda/stdx/mapx"
+	"github.com/gosuda/stdx/option"
+	"github.com/gosuda/stdx/result"
+)
+
+var _ mapx.Map[int, string] = (*ReadOnlyMap[int, string])(nil)
+
+// ReadOnlyMap adapts a persistent Map to the mapx.Map interface.
+// Put and Clear panic with mapx.ErrReadOnly and Remove returns Err(mapx.ErrReadOnly).
+type ReadOnlyMap[K comparable, V any] struct {
+	m *Map[K, V]
+}
+
+// Map returns the underlying persistent map.
+func (r *ReadOnlyMap[K, V]) Map() *Map[K, V] {
+	return r.m
+}
+
+// Clear implements mapx.Map. It always panics.
+func (r *ReadOnlyMap[K, V]) Clear() {
+	panic(mapx.ErrReadOnly)
+}
+
+// ContainsKey implements mapx.Map.
+func (r *ReadOnlyMap[K, V]) ContainsKey(key K) bool {
+	return r.m.ContainsKey(key)
+}
+
+// ContainsValue implements mapx.Map.
+func (r *ReadOnlyMap[K, V]) ContainsValue(value V) bool {
+	return r.FindKey(value).IsSome()
+}
+
+// Entries implements mapx.Map.
+func (r *ReadOnlyMap[K, V]) Entries() []mapx.Entry[K, V] {
+	result := make([]mapx.Entry[K, V], 0, r.m.Size())
+	for k, v := range r.m.All() {
+		result = append(result, mapx.Entry[K, V]{Key: k, Value: v})
+	}
+	return result
+}
+
+// ForEach implements mapx.Map.
+func (r *ReadOnlyMap[K, V]) ForEach(fn func(key K, value V)) {
+	r.m.ForEach(fn)
+}
+
+// Get implements mapx.Map.
+func (r *ReadOnlyMap[K, V]) Get(key K) option.Option[V] {
+	return r.m.Get(key)
+}
+
+// IsEmpty implements mapx.Map.
+func (r *ReadOnlyMap[K, V]) IsEmpty() bool {
+	return r.m.IsEmpty()
+}
+
+// Keys implements mapx.Map.
+func (r *ReadOnlyMap[K, V]) Keys() []K {
+	result := make([]K, 0, r.m.Size())
+	for k := range r.m.Keys() {
+		result = append(result, k)
+	}
+	return result
+}
+
+// Put implements mapx.Map. It always panics.
+func (r *ReadOnlyMap[K, V]) Put(key K, value V) option.Option[V] {
+	panic(mapx.ErrReadOnly)
+}
+
+// Remove implements mapx.Map. It always returns Err(mapx.ErrReadOnly).
+func (r *ReadOnlyMap[K, V]) Remove(key K) result.Result[V, error] {
+	return result.Err[V, error](mapx.ErrReadOnly)
+}
+
+// Size implements mapx.Map.
+func (r *ReadOnlyMap[K, V]) Size() int {
+	return r.m.Size()
+}
+
+// Values implements mapx.Map.
+func (r *ReadOnlyMap[K, V]) Values() []V {
+	result := make([]V, 0, r.m.Size())
+	for v := range r.m.Values() {
+		result = append(result, v)
+	}
+	return result
+}
+
+// FindKey implements mapx.Map.
+func (r *ReadOnlyMap[K, V]) FindKey(value V) option.Option[K] {
+	for k, v := range r.m.All() {
+		if reflect.DeepEqual(v, value) {
+			return option.Some(k)
+		}
+	}
+	return option.None[K]()
+}
+
+// FindEntry implements mapx.Map.
+func (r *ReadOnlyMap[K, V]) FindEntry(predicate func(K, V) bool) option.Option[mapx.Entry[K, V]] {
+	for k, v := range r.m.All() {
+		if predicate(k, v) {
+			return option.Some(mapx.Entry[K, V]{Key: k, Value: v})
+		}
+	}
+	return option.None[mapx.Entry[K, V]]()
+}
+
+// Filter implements mapx.Map. The returned map is also read-only.
+func (r *ReadOnlyMap[K, V]) Filter(predicate func(K, V) bool) mapx.Map[K, V] {
+	return r.m.Filter(predicate).ReadOnly()
+}
@@ -0,0 +1,78 @@
+package persistent_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gosuda/stdx/mapx"
+	"github.com/gosuda/stdx/mapx/persistent"
+)
+
+func newReadOnly() mapx.Map[string, int] {
+	return persistent.New[string, int]().Set("one", 1).Set("two", 2).Set("three", 3).ReadOnly()
+}
+
+func TestReadOnlyMap_Reads(t *testing.T) {
+	m := newReadOnly()
+
+	if m.Size() != 3 || m.IsEmpty() {
+		t.Errorf("Expected size 3, got %d", m.Size())
+	}
+	if m.Get("two").UnwrapOr(0) != 2 {
+		t.Error("Expected two=2")
+	}
+	if !m.ContainsKey("one") || m.ContainsKey("four") {
+		t.Error("ContainsKey returned wrong result")
+	}
+	if !m.ContainsValue(3) || m.ContainsValue(4) {
+		t.Error("ContainsValue returned wrong result")
+	}
+	if m.FindKey(1).UnwrapOr("") != "one" {
+		t.Error("FindKey should find one")
+	}
+	if len(m.Keys()) != 3 || len(m.Values()) != 3 || len(m.Entries()) != 3 {
+		t.Error("Keys, Values and Entries should have 3 elements")
+	}
+
+	entry := m.FindEntry(func(k string, v int) bool { return v > 2 })
+	if entry.IsNone() || entry.Unwrap().Key != "three" {
+		t.Error("FindEntry should find three")
+	}
+
+	filtered := m.Filter(func(k string, v int) bool { return v != 2 })
+	if filtered.Size() != 2 || filtered.ContainsKey("two") {
+		t.Error("Filter should drop two")
+	}
+
+	sum := 0
+	m.ForEach(func(k string, v int) { sum += v })
+	if sum != 6 {
+		t.Errorf("Expected sum 6, got %d", sum)
+	}
+}
+
+func TestReadOnlyMap_Mutations(t *testing.T) {
+	m := newReadOnly()
+
+	if err := m.Remove("one").UnwrapErr(); !errors.Is(err, mapx.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly, got %v", err)
+	}
+
+	for name, mutate := range map[string]func(){
+		"Put":   func() { m.Put("four", 4) },
+		"Clear": func() { m.Clear() },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r != mapx.ErrReadOnly {
+					t.Errorf("%s should panic with ErrReadOnly, got %v", name, r)
+				}
+			}()
+			mutate()
+		}()
+	}
+
+	if m.Size() != 3 {
+		t.Error("Read-only map should not be modified")
+	}
+}
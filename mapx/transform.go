@@ -0,0 +1,86 @@
+package mapx
+
+import (
+	"iter"
+)
+
+// All returns an iterator over all key-value pairs in the map.
+// Iteration stops early when the consumer stops ranging.
+func All[K comparable, V any](m Map[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.FindEntry(func(key K, value V) bool {
+			return !yield(key, value)
+		})
+	}
+}
+
+// MapValues stores every key of m with its value transformed by fn into dst and returns dst.
+func MapValues[K comparable, V, U any](m Map[K, V], dst Map[K, U], fn func(K, V) U) Map[K, U] {
+	m.ForEach(func(key K, value V) {
+		dst.Put(key, fn(key, value))
+	})
+	return dst
+}
+
+// MapKeys stores every value of m under the key computed by fn into dst and returns dst.
+// If fn maps several keys to the same new key, one of the values is kept and the others are discarded.
+func MapKeys[K, J comparable, V any](m Map[K, V], dst Map[J, V], fn func(K, V) J) Map[J, V] {
+	m.ForEach(func(key K, value V) {
+		dst.Put(fn(key, value), value)
+	})
+	return dst
+}
+
+// Invert stores every entry of m with key and value swapped into dst and returns dst.
+// If several keys share the same value, one of them is kept and the others are discarded.
+func Invert[K, V comparable](m Map[K, V], dst Map[V, K]) Map[V, K] {
+	m.ForEach(func(key K, value V) {
+		dst.Put(value, key)
+	})
+	return dst
+}
+
+// GroupBy groups the entries of m by the key computed by fn, stores the groups into dst and returns dst.
+func GroupBy[K, G comparable, V any](m Map[K, V], dst Map[G, []Entry[K, V]], fn func(K, V) G) Map[G, []Entry[K, V]] {
+	m.ForEach(func(key K, value V) {
+		group := fn(key, value)
+		entries := dst.Get(group).UnwrapOr(nil)
+		dst.Put(group, append(entries, Entry[K, V]{Key: key, Value: value}))
+	})
+	return dst
+}
+
+// Partition stores the entries of m matching the predicate into matched and the rest into unmatched.
+func Partition[K comparable, V any](m Map[K, V], matched, unmatched Map[K, V], predicate func(K, V) bool) (Map[K, V], Map[K, V]) {
+	m.ForEach(func(key K, value V) {
+		if predicate(key, value) {
+			matched.Put(key, value)
+		} else {
+			unmatched.Put(key, value)
+		}
+	})
+	return matched, unmatched
+}
+
+// Reduce folds every entry of m into an accumulator starting from initial.
+func Reduce[K comparable, V, A any](m Map[K, V], initial A, fn func(acc A, key K, value V) A) A {
+	acc := initial
+	m.ForEach(func(key K, value V) {
+		acc = fn(acc, key, value)
+	})
+	return acc
+}
+
+// MergeWith copies every entry of srcs into dst in order and returns dst.
+// When a key already exists in dst, conflict decides the stored value from the existing and incoming values.
+func MergeWith[K comparable, V any](dst Map[K, V], conflict func(key K, existing, incoming V) V, srcs ...Map[K, V]) Map[K, V] {
+	for _, src := range srcs {
+		src.ForEach(func(key K, value V) {
+			if existing := dst.Get(key); existing.IsSome() {
+				value = conflict(key, existing.Unwrap(), value)
+			}
+			dst.Put(key, value)
+		})
+	}
+	return dst
+}
@@ -0,0 +1,137 @@
+package mapx_test
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gosuda/stdx/mapx"
+	"github.com/gosuda/stdx/mapx/concurrentmap"
+	"github.com/gosuda/stdx/mapx/hashmap"
+)
+
+func newSample() mapx.Map[string, int] {
+	m := hashmap.New[string, int]()
+	m.Put("one", 1)
+	m.Put("two", 2)
+	m.Put("three", 3)
+	m.Put("four", 4)
+	return m
+}
+
+func TestAll(t *testing.T) {
+	m := newSample()
+
+	sum := 0
+	for _, v := range mapx.All(m) {
+		sum += v
+	}
+	if sum != 10 {
+		t.Errorf("Expected sum 10, got %d", sum)
+	}
+
+	seen := 0
+	for range mapx.All(m) {
+		seen++
+		break
+	}
+	if seen != 1 {
+		t.Errorf("Iteration should stop early, saw %d", seen)
+	}
+}
+
+func TestMapValues(t *testing.T) {
+	dst := mapx.MapValues(newSample(), concurrentmap.New[string, string](), func(k string, v int) string {
+		return strings.Repeat("*", v)
+	})
+
+	if _, ok := dst.(*concurrentmap.ConcurrentMap[string, string]); !ok {
+		t.Errorf("Expected the destination backend, got %T", dst)
+	}
+	if dst.Size() != 4 || dst.Get("three").UnwrapOr("") != "***" {
+		t.Error("MapValues should transform every value")
+	}
+}
+
+func TestMapKeys(t *testing.T) {
+	dst := mapx.MapKeys(newSample(), hashmap.New[int, int](), func(k string, v int) int {
+		return len(k)
+	})
+
+	// "one" and "two" collide on length 3, so only three keys remain.
+	if dst.Size() != 3 {
+		t.Errorf("Expected 3 keys, got %d", dst.Size())
+	}
+	if dst.Get(5).UnwrapOr(0) != 3 || dst.Get(4).UnwrapOr(0) != 4 {
+		t.Error("MapKeys should rekey every value")
+	}
+}
+
+func TestInvert(t *testing.T) {
+	dst := mapx.Invert(newSample(), hashmap.New[int, string]())
+
+	if dst.Size() != 4 || dst.Get(2).UnwrapOr("") != "two" {
+		t.Error("Invert should swap keys and values")
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	groups := mapx.GroupBy(newSample(), hashmap.New[bool, []mapx.Entry[string, int]](), func(k string, v int) bool {
+		return v%2 == 0
+	})
+
+	even := groups.Get(true).UnwrapOr(nil)
+	odd := groups.Get(false).UnwrapOr(nil)
+	if len(even) != 2 || len(odd) != 2 {
+		t.Fatalf("Expected 2 even and 2 odd entries, got %d and %d", len(even), len(odd))
+	}
+
+	keys := []string{even[0].Key, even[1].Key}
+	sort.Strings(keys)
+	if keys[0] != "four" || keys[1] != "two" {
+		t.Errorf("Unexpected even group %v", keys)
+	}
+}
+
+func TestPartition(t *testing.T) {
+	big, small := mapx.Partition(newSample(), hashmap.New[string, int](), hashmap.New[string, int](), func(k string, v int) bool {
+		return v > 2
+	})
+
+	if big.Size() != 2 || !big.ContainsKey("three") || !big.ContainsKey("four") {
+		t.Error("Matched partition should contain three and four")
+	}
+	if small.Size() != 2 || !small.ContainsKey("one") || !small.ContainsKey("two") {
+		t.Error("Unmatched partition should contain one and two")
+	}
+}
+
+func TestReduce(t *testing.T) {
+	total := mapx.Reduce(newSample(), 0, func(acc int, k string, v int) int {
+		return acc + len(k)*v
+	})
+
+	if total != 3+6+15+16 {
+		t.Errorf("Expected 40, got %d", total)
+	}
+}
+
+func TestMergeWith(t *testing.T) {
+	other := hashmap.New[string, int]()
+	other.Put("one", 10)
+	other.Put("five", 5)
+
+	dst := mapx.MergeWith(newSample(), func(k string, existing, incoming int) int {
+		return existing + incoming
+	}, other, other)
+
+	if dst.Size() != 5 {
+		t.Errorf("Expected 5 entries, got %d", dst.Size())
+	}
+	if dst.Get("one").UnwrapOr(0) != 21 {
+		t.Errorf("Expected one=21, got %v", dst.Get("one"))
+	}
+	if dst.Get("five").UnwrapOr(0) != 10 {
+		t.Errorf("Expected five=10, got %v", dst.Get("five"))
+	}
+}
@@ -0,0 +1,237 @@
+package mapx
+
+import (
+	"iter"
+	"reflect"
+
+	"github.com/gosuda/stdx/option"
+	"github.com/gosuda/stdx/result"
+)
+
+// KeyView is a live, read-only view of the keys of a Map.
+// It reflects later changes to the underlying map and never copies it.
+type KeyView[K comparable, V any] struct {
+	m Map[K, V]
+}
+
+// KeysView returns a live view of the keys of m.
+func KeysView[K comparable, V any](m Map[K, V]) KeyView[K, V] {
+	return KeyView[K, V]{m: m}
+}
+
+// Contains checks if the key exists in the underlying map.
+func (v KeyView[K, V]) Contains(key K) bool {
+	return v.m.ContainsKey(key)
+}
+
+// Size returns the number of keys.
+func (v KeyView[K, V]) Size() int {
+	return v.m.Size()
+}
+
+// IsEmpty checks if there are no keys.
+func (v KeyView[K, V]) IsEmpty() bool {
+	return v.m.IsEmpty()
+}
+
+// ForEach executes a function for every key.
+func (v KeyView[K, V]) ForEach(fn func(key K)) {
+	v.m.ForEach(func(key K, _ V) {
+		fn(key)
+	})
+}
+
+// All returns an iterator over the keys.
+func (v KeyView[K, V]) All() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for key := range All(v.m) {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice returns the current keys as a slice.
+func (v KeyView[K, V]) ToSlice() []K {
+	return v.m.Keys()
+}
+
+// ValueView is a live, read-only view of the values of a Map.
+// It reflects later changes to the underlying map and never copies it.
+type ValueView[K comparable, V any] struct {
+	m Map[K, V]
+}
+
+// ValuesView returns a live view of the values of m.
+func ValuesView[K comparable, V any](m Map[K, V]) ValueView[K, V] {
+	return ValueView[K, V]{m: m}
+}
+
+// Contains checks if the value exists in the underlying map.
+func (v ValueView[K, V]) Contains(value V) bool {
+	return v.m.ContainsValue(value)
+}
+
+// Size returns the number of values.
+func (v ValueView[K, V]) Size() int {
+	return v.m.Size()
+}
+
+// IsEmpty checks if there are no values.
+func (v ValueView[K, V]) IsEmpty() bool {
+	return v.m.IsEmpty()
+}
+
+// ForEach executes a function for every value.
+func (v ValueView[K, V]) ForEach(fn func(value V)) {
+	v.m.ForEach(func(_ K, value V) {
+		fn(value)
+	})
+}
+
+// All returns an iterator over the values.
+func (v ValueView[K, V]) All() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, value := range All(v.m) {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice returns the current values as a slice.
+func (v ValueView[K, V]) ToSlice() []V {
+	return v.m.Values()
+}
+
+var _ Map[int, string] = (*FilteredMap[int, string])(nil)
+
+// FilteredMap is a live view of the entries of a Map that match a predicate.
+// Reads only see matching entries; writes go through to the underlying map.
+type FilteredMap[K comparable, V any] struct {
+	m         Map[K, V]
+	predicate func(K, V) bool
+}
+
+// FilterView returns a live view of the entries of m that match the predicate.
+func FilterView[K comparable, V any](m Map[K, V], predicate func(K, V) bool) *FilteredMap[K, V] {
+	return &FilteredMap[K, V]{m: m, predicate: predicate}
+}
+
+// visible returns Some(value) if key exists and matches the predicate, None otherwise.
+func (f *FilteredMap[K, V]) visible(key K) option.Option[V] {
+	return f.m.Get(key).Filter(func(value V) bool {
+		return f.predicate(key, value)
+	})
+}
+
+// Put implements Map. The entry is stored in the underlying map even if it does not match the predicate.
+// It returns the value previously stored in the underlying map, even if that value was hidden.
+func (f *FilteredMap[K, V]) Put(key K, value V) option.Option[V] {
+	return f.m.Put(key, value)
+}
+
+// Get implements Map.
+func (f *FilteredMap[K, V]) Get(key K) option.Option[V] {
+	return f.visible(key)
+}
+
+// Remove implements Map. Entries that do not match the predicate are left untouched.
+func (f *FilteredMap[K, V]) Remove(key K) result.Result[V, error] {
+	if f.visible(key).IsNone() {
+		return result.Err[V, error](ErrKeyNotFound)
+	}
+	return f.m.Remove(key)
+}
+
+// ContainsKey implements Map.
+func (f *FilteredMap[K, V]) ContainsKey(key K) bool {
+	return f.visible(key).IsSome()
+}
+
+// ContainsValue implements Map.
+func (f *FilteredMap[K, V]) ContainsValue(value V) bool {
+	return f.FindKey(value).IsSome()
+}
+
+// Size implements Map. It counts the matching entries on every call.
+func (f *FilteredMap[K, V]) Size() int {
+	count := 0
+	f.ForEach(func(K, V) {
+		count++
+	})
+	return count
+}
+
+// IsEmpty implements Map.
+func (f *FilteredMap[K, V]) IsEmpty() bool {
+	return f.FindEntry(func(K, V) bool { return true }).IsNone()
+}
+
+// Clear implements Map. Only matching entries are removed from the underlying map.
+func (f *FilteredMap[K, V]) Clear() {
+	for _, key := range f.Keys() {
+		f.m.Remove(key)
+	}
+}
+
+// Keys implements Map.
+func (f *FilteredMap[K, V]) Keys() []K {
+	var result []K
+	f.ForEach(func(key K, _ V) {
+		result = append(result, key)
+	})
+	return result
+}
+
+// Values implements Map.
+func (f *FilteredMap[K, V]) Values() []V {
+	var result []V
+	f.ForEach(func(_ K, value V) {
+		result = append(result, value)
+	})
+	return result
+}
+
+// Entries implements Map.
+func (f *FilteredMap[K, V]) Entries() []Entry[K, V] {
+	var result []Entry[K, V]
+	f.ForEach(func(key K, value V) {
+		result = append(result, Entry[K, V]{Key: key, Value: value})
+	})
+	return result
+}
+
+// ForEach implements Map.
+func (f *FilteredMap[K, V]) ForEach(fn func(key K, value V)) {
+	f.m.ForEach(func(key K, value V) {
+		if f.predicate(key, value) {
+			fn(key, value)
+		}
+	})
+}
+
+// FindKey implements Map.
+func (f *FilteredMap[K, V]) FindKey(value V) option.Option[K] {
+	entry := f.FindEntry(func(_ K, v V) bool {
+		return reflect.DeepEqual(v, value)
+	})
+	return option.Map(entry, func(e Entry[K, V]) K { return e.Key })
+}
+
+// FindEntry implements Map.
+func (f *FilteredMap[K, V]) FindEntry(predicate func(K, V) bool) option.Option[Entry[K, V]] {
+	return f.m.FindEntry(func(key K, value V) bool {
+		return f.predicate(key, value) && predicate(key, value)
+	})
+}
+
+// Filter implements Map. Like the underlying map's Filter, it returns a new map of the
+// matching entries; use FilterView on the view to combine predicates lazily.
+func (f *FilteredMap[K, V]) Filter(predicate func(K, V) bool) Map[K, V] {
+	return f.m.Filter(func(key K, value V) bool {
+		return f.predicate(key, value) && predicate(key, value)
+	})
+}
@@ -0,0 +1,108 @@
+package mapx_test
+
+import (
+	"testing"
+
+	"github.com/gosuda/stdx/mapx"
+)
+
+func TestKeysView(t *testing.T) {
+	m := newSample()
+	keys := mapx.KeysView(m)
+
+	if keys.Size() != 4 || !keys.Contains("two") {
+		t.Error("KeysView should see the map keys")
+	}
+
+	m.Put("five", 5)
+	if keys.Size() != 5 || !keys.Contains("five") {
+		t.Error("KeysView should reflect later changes")
+	}
+
+	count := 0
+	for range keys.All() {
+		count++
+	}
+	if count != 5 || len(keys.ToSlice()) != 5 {
+		t.Errorf("Expected 5 keys, got %d", count)
+	}
+
+	m.Clear()
+	if !keys.IsEmpty() {
+		t.Error("KeysView should be empty after Clear")
+	}
+}
+
+func TestValuesView(t *testing.T) {
+	m := newSample()
+	values := mapx.ValuesView(m)
+
+	if !values.Contains(3) || values.Contains(5) {
+		t.Error("ValuesView Contains returned wrong result")
+	}
+
+	m.Put("five", 5)
+	sum := 0
+	values.ForEach(func(v int) { sum += v })
+	if sum != 15 {
+		t.Errorf("Expected sum 15, got %d", sum)
+	}
+
+	sum = 0
+	for v := range values.All() {
+		sum += v
+	}
+	if sum != 15 || values.Size() != 5 {
+		t.Errorf("Expected sum 15 from All, got %d", sum)
+	}
+}
+
+func TestFilterView(t *testing.T) {
+	m := newSample()
+	even := mapx.FilterView(m, func(k string, v int) bool { return v%2 == 0 })
+
+	if even.Size() != 2 || even.ContainsKey("one") || !even.ContainsKey("two") {
+		t.Error("FilterView should only see matching entries")
+	}
+	if even.Get("three").IsSome() || even.Get("four").UnwrapOr(0) != 4 {
+		t.Error("Get should only return matching entries")
+	}
+	if !even.ContainsValue(2) || even.ContainsValue(3) {
+		t.Error("ContainsValue should only see matching entries")
+	}
+
+	// Writes go through to the underlying map.
+	if even.Put("six", 6).IsSome() {
+		t.Error("Put of new key should return None")
+	}
+	if !m.ContainsKey("six") || even.Size() != 3 {
+		t.Error("Put should write through to the underlying map")
+	}
+	if even.Put("one", 11).UnwrapOr(0) != 1 {
+		t.Error("Put over hidden entry should return the hidden value")
+	}
+	if m.Get("one").Unwrap() != 11 || even.ContainsKey("one") {
+		t.Error("Non-matching entry should be stored but hidden")
+	}
+
+	if even.Remove("three").IsOk() {
+		t.Error("Remove of hidden entry should fail")
+	}
+	if even.Remove("two").UnwrapOr(0) != 2 || m.ContainsKey("two") {
+		t.Error("Remove of visible entry should remove it from the underlying map")
+	}
+
+	big := even.Filter(func(k string, v int) bool { return v > 4 })
+	if big.Size() != 1 || !big.ContainsKey("six") {
+		t.Error("Nested filter should combine predicates")
+	}
+	m.Put("eight", 8)
+	if big.ContainsKey("eight") || !even.ContainsKey("eight") {
+		t.Error("Filter should return a new map, not a live view")
+	}
+
+	even.Clear()
+	if !even.IsEmpty() || m.Size() != 2 {
+		t.Errorf("Clear should only remove matching entries, %d left", m.Size())
+	}
+}
@@ -0,0 +1,233 @@
+package weakmap
+
+import (
+	"reflect"
+	"runtime"
+	"weak"
+
+	"github.com/gosuda/stdx/mapx"
+	"github.com/gosuda/stdx/option"
+	"github.com/gosuda/stdx/result"
+)
+
+var _ mapx.Map[*int, string] = (*KeyMap[int, string])(nil)
+
+// keyEntry is a value stored under a weak key and the cleanup that reports the key's death.
+type keyEntry[V any] struct {
+	value   V
+	cleanup runtime.Cleanup
+}
+
+// KeyMap is a map whose pointer keys are held weakly and compared by identity.
+// An entry disappears once its key is no longer reachable from outside the map.
+// Values must not reference their own key, otherwise the key never becomes unreachable.
+// Nil keys are ignored.
+type KeyMap[K, V any] struct {
+	mu         rwLocker
+	concurrent bool
+	elements   map[weak.Pointer[K]]keyEntry[V]
+	dead       *graveyard[weak.Pointer[K]]
+}
+
+// NewKeyMap creates a weak-keyed map that is not safe for concurrent use.
+func NewKeyMap[K, V any]() *KeyMap[K, V] {
+	return newKeyMap[K, V](false)
+}
+
+// NewConcurrentKeyMap creates a weak-keyed map that is safe for concurrent use.
+func NewConcurrentKeyMap[K, V any]() *KeyMap[K, V] {
+	return newKeyMap[K, V](true)
+}
+
+func newKeyMap[K, V any](concurrent bool) *KeyMap[K, V] {
+	return &KeyMap[K, V]{
+		mu:         newLocker(concurrent),
+		concurrent: concurrent,
+		elements:   make(map[weak.Pointer[K]]keyEntry[V]),
+		dead:       &graveyard[weak.Pointer[K]]{},
+	}
+}
+
+// purge removes entries whose keys were collected. The caller must hold the write lock.
+func (w *KeyMap[K, V]) purge() {
+	for _, ptr := range w.dead.drain() {
+		delete(w.elements, ptr)
+	}
+}
+
+// tidy purges dead entries if the write lock is free. Readers call it so that
+// dead entries do not pile up in maps that are rarely modified.
+func (w *KeyMap[K, V]) tidy() {
+	if w.dead.empty() || !w.mu.TryLock() {
+		return
+	}
+	w.purge()
+	w.mu.Unlock()
+}
+
+// live returns a snapshot of all entries whose keys are still reachable.
+func (w *KeyMap[K, V]) live() []mapx.Entry[*K, V] {
+	w.tidy()
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	result := make([]mapx.Entry[*K, V], 0, len(w.elements))
+	for ptr, e := range w.elements {
+		if k := ptr.Value(); k != nil {
+			result = append(result, mapx.Entry[*K, V]{Key: k, Value: e.value})
+		}
+	}
+	return result
+}
+
+// Clear implements mapx.Map.
+func (w *KeyMap[K, V]) Clear() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for ptr, e := range w.elements {
+		k := ptr.Value()
+		e.cleanup.Stop()
+		runtime.KeepAlive(k)
+	}
+	w.elements = make(map[weak.Pointer[K]]keyEntry[V])
+	w.dead.drain()
+}
+
+// ContainsKey implements mapx.Map.
+func (w *KeyMap[K, V]) ContainsKey(key *K) bool {
+	return w.Get(key).IsSome()
+}
+
+// ContainsValue implements mapx.Map.
+func (w *KeyMap[K, V]) ContainsValue(value V) bool {
+	return w.FindKey(value).IsSome()
+}
+
+// Entries implements mapx.Map.
+func (w *KeyMap[K, V]) Entries() []mapx.Entry[*K, V] {
+	return w.live()
+}
+
+// ForEach implements mapx.Map. fn is called on a snapshot and may modify the map.
+func (w *KeyMap[K, V]) ForEach(fn func(key *K, value V)) {
+	for _, e := range w.live() {
+		fn(e.Key, e.Value)
+	}
+}
+
+// Get implements mapx.Map.
+func (w *KeyMap[K, V]) Get(key *K) option.Option[V] {
+	if key == nil {
+		return option.None[V]()
+	}
+	w.tidy()
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if e, exists := w.elements[weak.Make(key)]; exists {
+		return option.Some(e.value)
+	}
+	return option.None[V]()
+}
+
+// IsEmpty implements mapx.Map.
+func (w *KeyMap[K, V]) IsEmpty() bool {
+	return w.Size() == 0
+}
+
+// Keys implements mapx.Map.
+func (w *KeyMap[K, V]) Keys() []*K {
+	entries := w.live()
+	result := make([]*K, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, e.Key)
+	}
+	return result
+}
+
+// Put implements mapx.Map.
+func (w *KeyMap[K, V]) Put(key *K, value V) option.Option[V] {
+	if key == nil {
+		return option.None[V]()
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.purge()
+
+	ptr := weak.Make(key)
+	if e, exists := w.elements[ptr]; exists {
+		w.elements[ptr] = keyEntry[V]{value: value, cleanup: e.cleanup}
+		return option.Some(e.value)
+	}
+	w.elements[ptr] = keyEntry[V]{
+		value:   value,
+		cleanup: runtime.AddCleanup(key, w.dead.bury, ptr),
+	}
+	return option.None[V]()
+}
+
+// Remove implements mapx.Map.
+func (w *KeyMap[K, V]) Remove(key *K) result.Result[V, error] {
+	if key != nil {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		w.purge()
+
+		ptr := weak.Make(key)
+		if e, exists := w.elements[ptr]; exists {
+			delete(w.elements, ptr)
+			e.cleanup.Stop()
+			runtime.KeepAlive(key)
+			return result.Ok[V, error](e.value)
+		}
+	}
+	return result.Err[V, error](mapx.ErrKeyNotFound)
+}
+
+// Size implements mapx.Map. It runs in constant time, so an entry whose key was
+// collected is counted until its cleanup has run and the map has purged it.
+func (w *KeyMap[K, V]) Size() int {
+	w.tidy()
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return len(w.elements)
+}
+
+// Values implements mapx.Map.
+func (w *KeyMap[K, V]) Values() []V {
+	entries := w.live()
+	result := make([]V, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, e.Value)
+	}
+	return result
+}
+
+// FindKey implements mapx.Map.
+func (w *KeyMap[K, V]) FindKey(value V) option.Option[*K] {
+	for _, e := range w.live() {
+		if reflect.DeepEqual(e.Value, value) {
+			return option.Some(e.Key)
+		}
+	}
+	return option.None[*K]()
+}
+
+// FindEntry implements mapx.Map.
+func (w *KeyMap[K, V]) FindEntry(predicate func(*K, V) bool) option.Option[mapx.Entry[*K, V]] {
+	for _, e := range w.live() {
+		if predicate(e.Key, e.Value) {
+			return option.Some(e)
+		}
+	}
+	return option.None[mapx.Entry[*K, V]]()
+}
+
+// Filter implements mapx.Map. The returned map has the same concurrency mode as the receiver.
+func (w *KeyMap[K, V]) Filter(predicate func(*K, V) bool) mapx.Map[*K, V] {
+	result := newKeyMap[K, V](w.concurrent)
+	for _, e := range w.live() {
+		if predicate(e.Key, e.Value) {
+			result.Put(e.Key, e.Value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,125 @@
+package weakmap_test
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/gosuda/stdx/mapx/weakmap"
+)
+
+func TestKeyMap_Basic(t *testing.T) {
+	m := weakmap.NewKeyMap[payload, int]()
+	a := &payload{name: "a"}
+	b := &payload{name: "a"}
+
+	if m.Put(a, 1).IsSome() {
+		t.Error("Put of new key should return None")
+	}
+	m.Put(b, 2)
+
+	if m.Size() != 2 {
+		t.Errorf("Keys should be compared by identity, got size %d", m.Size())
+	}
+	if m.Get(a).UnwrapOr(0) != 1 || m.Get(b).UnwrapOr(0) != 2 {
+		t.Error("Get should return the value stored for each key")
+	}
+	if m.Get(&payload{name: "a"}).IsSome() {
+		t.Error("Unknown key should not be found")
+	}
+	if !m.ContainsValue(2) || m.FindKey(2).UnwrapOr(nil) != b {
+		t.Error("FindKey should find b")
+	}
+
+	if m.Put(a, 10).UnwrapOr(0) != 1 {
+		t.Error("Put should return previous value")
+	}
+	if m.Remove(a).UnwrapOr(0) != 10 {
+		t.Error("Remove should return removed value")
+	}
+	if m.Remove(a).IsOk() || m.Remove(nil).IsOk() {
+		t.Error("Removing missing key should fail")
+	}
+	if m.Put(nil, 3).IsSome() || m.ContainsKey(nil) {
+		t.Error("Nil keys should be ignored")
+	}
+
+	m.Clear()
+	if !m.IsEmpty() {
+		t.Error("Map should be empty after Clear")
+	}
+	runtime.KeepAlive(a)
+	runtime.KeepAlive(b)
+}
+
+func TestKeyMap_Collected(t *testing.T) {
+	m := weakmap.NewKeyMap[payload, string]()
+	kept := &payload{name: "kept"}
+	m.Put(kept, "kept")
+	m.Put(&payload{name: "dropped"}, "dropped")
+
+	eventually(t, func() bool { return m.Size() == 1 })
+
+	if !m.ContainsValue("kept") || m.ContainsValue("dropped") {
+		t.Error("Only the entry with a reachable key should remain")
+	}
+	if keys := m.Keys(); len(keys) != 1 || keys[0] != kept {
+		t.Errorf("Unexpected keys %v", keys)
+	}
+
+	m.Put(kept, "again")
+	if len(m.Entries()) != 1 || len(m.Values()) != 1 {
+		t.Error("Expected a single entry after purge")
+	}
+
+	filtered := m.Filter(func(k *payload, v string) bool { return v == "again" })
+	if filtered.Size() != 1 || !filtered.ContainsKey(kept) {
+		t.Error("Filter should keep the live entry")
+	}
+	runtime.KeepAlive(kept)
+}
+
+func TestKeyMap_PurgeOnRead(t *testing.T) {
+	m := weakmap.NewConcurrentKeyMap[payload, int]()
+	kept := &payload{name: "kept"}
+	m.Put(kept, 0)
+	for i := 1; i <= 100; i++ {
+		m.Put(&payload{name: "dropped"}, i)
+	}
+
+	// Only reads from here on; dead entries must still be purged.
+	eventually(t, func() bool { return m.Size() == 1 })
+	if len(m.Entries()) != 1 || m.Get(kept).UnwrapOr(-1) != 0 {
+		t.Error("Only the entry with a reachable key should remain")
+	}
+	runtime.KeepAlive(kept)
+}
+
+func TestKeyMap_Concurrent(t *testing.T) {
+	m := weakmap.NewConcurrentKeyMap[payload, int]()
+	keys := make([]*payload, 100)
+	for i := range keys {
+		keys[i] = &payload{}
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i, k := range keys {
+				m.Put(k, i)
+				m.Get(k)
+				m.Keys()
+				m.Put(&payload{}, -1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	eventually(t, func() bool { return !m.ContainsValue(-1) })
+	if m.Size() != len(keys) {
+		t.Errorf("Expected %d entries, got %d", len(keys), m.Size())
+	}
+	runtime.KeepAlive(keys)
+}
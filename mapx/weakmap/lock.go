@@ -0,0 +1,68 @@
+// Package weakmap provides maps that hold their values or keys weakly.
+// Entries disappear once the weakly held pointer is otherwise unreachable,
+// which makes these maps suitable for caches and canonicalization tables.
+package weakmap
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// rwLocker is the subset of sync.RWMutex used by the maps in this package.
+type rwLocker interface {
+	Lock()
+	TryLock() bool
+	Unlock()
+	RLock()
+	RUnlock()
+}
+
+// noLock is used by maps that are not safe for concurrent use.
+type noLock struct{}
+
+func (noLock) Lock()         {}
+func (noLock) TryLock() bool { return true }
+func (noLock) Unlock()       {}
+func (noLock) RLock()        {}
+func (noLock) RUnlock()      {}
+
+// newLocker returns a real lock for concurrent maps and a no-op lock otherwise.
+func newLocker(concurrent bool) rwLocker {
+	if concurrent {
+		return &sync.RWMutex{}
+	}
+	return noLock{}
+}
+
+// graveyard collects entries whose weak pointers died.
+// Cleanups run on a separate goroutine, so they only record dead entries here
+// and the owning map removes them on its next modification, or on a read that
+// can take the write lock without waiting.
+type graveyard[T comparable] struct {
+	mu      sync.Mutex
+	dead    []T
+	pending atomic.Bool
+}
+
+// bury records a dead entry. It is called from runtime cleanups.
+func (g *graveyard[T]) bury(item T) {
+	g.mu.Lock()
+	g.dead = append(g.dead, item)
+	g.pending.Store(true)
+	g.mu.Unlock()
+}
+
+// empty reports whether there are no recorded dead entries. It does not lock.
+func (g *graveyard[T]) empty() bool {
+	return !g.pending.Load()
+}
+
+// drain returns and forgets all recorded dead entries.
+func (g *graveyard[T]) drain() []T {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	dead := g.dead
+	g.dead = nil
+	g.pending.Store(false)
+	return dead
+}
@@ -0,0 +1,245 @@
+package weakmap
+
+import (
+	"reflect"
+	"runtime"
+	"weak"
+
+	"github.com/gosuda/stdx/mapx"
+	"github.com/gosuda/stdx/option"
+	"github.com/gosuda/stdx/result"
+)
+
+var _ mapx.Map[int, *string] = (*ValueMap[int, string])(nil)
+
+// valueEntry is a weakly held value and the cleanup that reports its death.
+type valueEntry[V any] struct {
+	ptr     weak.Pointer[V]
+	cleanup runtime.Cleanup
+}
+
+// deadValue identifies an entry whose value was collected.
+type deadValue[K comparable, V any] struct {
+	key K
+	ptr weak.Pointer[V]
+}
+
+// ValueMap is a map whose values are held weakly.
+// An entry disappears once its value is no longer reachable from outside the map.
+// Putting a nil value removes the key.
+type ValueMap[K comparable, V any] struct {
+	mu         rwLocker
+	concurrent bool
+	elements   map[K]valueEntry[V]
+	dead       *graveyard[deadValue[K, V]]
+}
+
+// NewValueMap creates a weak-valued map that is not safe for concurrent use.
+func NewValueMap[K comparable, V any]() *ValueMap[K, V] {
+	return newValueMap[K, V](false)
+}
+
+// NewConcurrentValueMap creates a weak-valued map that is safe for concurrent use.
+func NewConcurrentValueMap[K comparable, V any]() *ValueMap[K, V] {
+	return newValueMap[K, V](true)
+}
+
+func newValueMap[K comparable, V any](concurrent bool) *ValueMap[K, V] {
+	return &ValueMap[K, V]{
+		mu:         newLocker(concurrent),
+		concurrent: concurrent,
+		elements:   make(map[K]valueEntry[V]),
+		dead:       &graveyard[deadValue[K, V]]{},
+	}
+}
+
+// purge removes entries whose values were collected. The caller must hold the write lock.
+func (w *ValueMap[K, V]) purge() {
+	for _, d := range w.dead.drain() {
+		if e, exists := w.elements[d.key]; exists && e.ptr == d.ptr {
+			delete(w.elements, d.key)
+		}
+	}
+}
+
+// tidy purges dead entries if the write lock is free. Readers call it so that
+// dead entries do not pile up in maps that are rarely modified.
+func (w *ValueMap[K, V]) tidy() {
+	if w.dead.empty() || !w.mu.TryLock() {
+		return
+	}
+	w.purge()
+	w.mu.Unlock()
+}
+
+// live returns a snapshot of all entries whose values are still reachable.
+func (w *ValueMap[K, V]) live() []mapx.Entry[K, *V] {
+	w.tidy()
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	result := make([]mapx.Entry[K, *V], 0, len(w.elements))
+	for k, e := range w.elements {
+		if v := e.ptr.Value(); v != nil {
+			result = append(result, mapx.Entry[K, *V]{Key: k, Value: v})
+		}
+	}
+	return result
+}
+
+// Clear implements mapx.Map.
+func (w *ValueMap[K, V]) Clear() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, e := range w.elements {
+		v := e.ptr.Value()
+		e.cleanup.Stop()
+		runtime.KeepAlive(v)
+	}
+	w.elements = make(map[K]valueEntry[V])
+	w.dead.drain()
+}
+
+// ContainsKey implements mapx.Map.
+func (w *ValueMap[K, V]) ContainsKey(key K) bool {
+	return w.Get(key).IsSome()
+}
+
+// ContainsValue implements mapx.Map.
+func (w *ValueMap[K, V]) ContainsValue(value *V) bool {
+	return w.FindKey(value).IsSome()
+}
+
+// Entries implements mapx.Map.
+func (w *ValueMap[K, V]) Entries() []mapx.Entry[K, *V] {
+	return w.live()
+}
+
+// ForEach implements mapx.Map. fn is called on a snapshot and may modify the map.
+func (w *ValueMap[K, V]) ForEach(fn func(key K, value *V)) {
+	for _, e := range w.live() {
+		fn(e.Key, e.Value)
+	}
+}
+
+// Get implements mapx.Map.
+func (w *ValueMap[K, V]) Get(key K) option.Option[*V] {
+	w.tidy()
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if e, exists := w.elements[key]; exists {
+		if v := e.ptr.Value(); v != nil {
+			return option.Some(v)
+		}
+	}
+	return option.None[*V]()
+}
+
+// IsEmpty implements mapx.Map.
+func (w *ValueMap[K, V]) IsEmpty() bool {
+	return w.Size() == 0
+}
+
+// Keys implements mapx.Map.
+func (w *ValueMap[K, V]) Keys() []K {
+	entries := w.live()
+	result := make([]K, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, e.Key)
+	}
+	return result
+}
+
+// Put implements mapx.Map.
+func (w *ValueMap[K, V]) Put(key K, value *V) option.Option[*V] {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.purge()
+
+	previous := option.None[*V]()
+	if e, exists := w.elements[key]; exists {
+		old := e.ptr.Value()
+		e.cleanup.Stop()
+		runtime.KeepAlive(old)
+		if old != nil {
+			previous = option.Some(old)
+		}
+		delete(w.elements, key)
+	}
+	if value == nil {
+		return previous
+	}
+
+	ptr := weak.Make(value)
+	w.elements[key] = valueEntry[V]{
+		ptr:     ptr,
+		cleanup: runtime.AddCleanup(value, w.dead.bury, deadValue[K, V]{key: key, ptr: ptr}),
+	}
+	return previous
+}
+
+// Remove implements mapx.Map.
+func (w *ValueMap[K, V]) Remove(key K) result.Result[*V, error] {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.purge()
+
+	if e, exists := w.elements[key]; exists {
+		delete(w.elements, key)
+		if v := e.ptr.Value(); v != nil {
+			e.cleanup.Stop()
+			runtime.KeepAlive(v)
+			return result.Ok[*V, error](v)
+		}
+	}
+	return result.Err[*V, error](mapx.ErrKeyNotFound)
+}
+
+// Size implements mapx.Map. It runs in constant time, so an entry whose value was
+// collected is counted until its cleanup has run and the map has purged it.
+func (w *ValueMap[K, V]) Size() int {
+	w.tidy()
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return len(w.elements)
+}
+
+// Values implements mapx.Map.
+func (w *ValueMap[K, V]) Values() []*V {
+	entries := w.live()
+	result := make([]*V, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, e.Value)
+	}
+	return result
+}
+
+// FindKey implements mapx.Map.
+func (w *ValueMap[K, V]) FindKey(value *V) option.Option[K] {
+	for _, e := range w.live() {
+		if reflect.DeepEqual(e.Value, value) {
+			return option.Some(e.Key)
+		}
+	}
+	return option.None[K]()
+}
+
+// FindEntry implements mapx.Map.
+func (w *ValueMap[K, V]) FindEntry(predicate func(K, *V) bool) option.Option[mapx.Entry[K, *V]] {
+	for _, e := range w.live() {
+		if predicate(e.Key, e.Value) {
+			return option.Some(e)
+		}
+	}
+	return option.None[mapx.Entry[K, *V]]()
+}
+
+// Filter implements mapx.Map. The returned map has the same concurrency mode as the receiver.
+func (w *ValueMap[K, V]) Filter(predicate func(K, *V) bool) mapx.Map[K, *V] {
+	result := newValueMap[K, V](w.concurrent)
+	for _, e := range w.live() {
+		if predicate(e.Key, e.Value) {
+			result.Put(e.Key, e.Value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,175 @@
+package weakmap_test
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gosuda/stdx/mapx/weakmap"
+)
+
+// payload is large enough and contains a pointer so it is never tiny-allocated,
+// which would delay collection.
+type payload struct {
+	name string
+	data [64]byte
+}
+
+// eventually runs the garbage collector until cond holds or the deadline passes.
+func eventually(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("Condition not met after garbage collection")
+		}
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestValueMap_Basic(t *testing.T) {
+	m := weakmap.NewValueMap[string, payload]()
+	a := &payload{name: "a"}
+	b := &payload{name: "b"}
+
+	if m.Put("a", a).IsSome() {
+		t.Error("Put of new key should return None")
+	}
+	m.Put("b", b)
+
+	if m.Get("a").UnwrapOr(nil) != a {
+		t.Error("Get should return the stored pointer")
+	}
+	if m.Size() != 2 || m.IsEmpty() {
+		t.Errorf("Expected size 2, got %d", m.Size())
+	}
+	if !m.ContainsValue(&payload{name: "b"}) {
+		t.Error("ContainsValue should compare values deeply")
+	}
+	if m.FindKey(b).UnwrapOr("") != "b" {
+		t.Error("FindKey should find b")
+	}
+
+	if m.Put("a", b).UnwrapOr(nil) != a {
+		t.Error("Put should return previous value")
+	}
+	if m.Remove("a").UnwrapOr(nil) != b {
+		t.Error("Remove should return removed value")
+	}
+	if m.Remove("a").IsOk() {
+		t.Error("Removing missing key should fail")
+	}
+
+	m.Put("b", nil)
+	if m.ContainsKey("b") {
+		t.Error("Putting nil should remove the key")
+	}
+
+	m.Put("a", a)
+	m.Clear()
+	if !m.IsEmpty() {
+		t.Error("Map should be empty after Clear")
+	}
+	runtime.KeepAlive(a)
+	runtime.KeepAlive(b)
+}
+
+func TestValueMap_Collected(t *testing.T) {
+	m := weakmap.NewValueMap[int, payload]()
+	kept := &payload{name: "kept"}
+	m.Put(1, kept)
+	m.Put(2, &payload{name: "dropped"})
+
+	eventually(t, func() bool { return !m.ContainsKey(2) && m.Size() == 1 })
+
+	if m.Size() != 1 || len(m.Keys()) != 1 || len(m.Values()) != 1 {
+		t.Errorf("Expected a single live entry, got %d", m.Size())
+	}
+	if m.Get(1).UnwrapOr(nil) != kept {
+		t.Error("Reachable value should stay in the map")
+	}
+
+	// Re-using a key whose value died must not be removed by the old cleanup.
+	fresh := &payload{name: "fresh"}
+	m.Put(2, fresh)
+	runtime.GC()
+	m.Put(3, kept)
+	if m.Get(2).UnwrapOr(nil) != fresh {
+		t.Error("Stale cleanup should not remove a newer entry")
+	}
+	runtime.KeepAlive(kept)
+	runtime.KeepAlive(fresh)
+}
+
+func TestValueMap_PurgeOnRead(t *testing.T) {
+	for _, m := range []*weakmap.ValueMap[int, payload]{
+		weakmap.NewValueMap[int, payload](),
+		weakmap.NewConcurrentValueMap[int, payload](),
+	} {
+		kept := &payload{name: "kept"}
+		m.Put(0, kept)
+		for i := 1; i <= 100; i++ {
+			m.Put(i, &payload{name: "dropped"})
+		}
+
+		// Only reads from here on; dead entries must still be purged.
+		eventually(t, func() bool { return m.Size() == 1 })
+		if m.Get(0).UnwrapOr(nil) != kept {
+			t.Error("Reachable value should stay in the map")
+		}
+		runtime.KeepAlive(kept)
+	}
+}
+
+func TestValueMap_Filter(t *testing.T) {
+	m := weakmap.NewValueMap[int, payload]()
+	values := []*payload{{name: "x"}, {name: "y"}, {name: "z"}}
+	for i, v := range values {
+		m.Put(i, v)
+	}
+
+	filtered := m.Filter(func(k int, v *payload) bool { return v.name != "y" })
+	if filtered.Size() != 2 || filtered.ContainsKey(1) {
+		t.Error("Filter should drop y")
+	}
+	if m.FindEntry(func(k int, v *payload) bool { return v.name == "z" }).IsNone() {
+		t.Error("FindEntry should find z")
+	}
+	runtime.KeepAlive(values)
+}
+
+func TestValueMap_Concurrent(t *testing.T) {
+	m := weakmap.NewConcurrentValueMap[int, payload]()
+	values := make([]*payload, 100)
+	for i := range values {
+		values[i] = &payload{}
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i, v := range values {
+				m.Put(i, v)
+				m.Get(i)
+				m.Size()
+				if i%3 == 0 {
+					m.Remove(i)
+				}
+				m.Put(1000+i, &payload{})
+			}
+		}()
+	}
+	wg.Wait()
+
+	eventually(t, func() bool { return !m.ContainsKey(1000) })
+	for i := 1; i < len(values); i += 3 {
+		if !m.ContainsKey(i) {
+			t.Errorf("Expected key %d to remain", i)
+		}
+	}
+	runtime.KeepAlive(values)
+}
@@ -0,0 +1,112 @@
+package option
+
+import "iter"
+
+// Sequence returns Some of all contained values if every Option is Some, otherwise returns None.
+// An empty slice yields Some of an empty slice.
+func Sequence[T any](opts []Option[T]) Option[[]T] {
+	values := make([]T, 0, len(opts))
+	for _, o := range opts {
+		if o.IsNone() {
+			return None[[]T]()
+		}
+		values = append(values, *o.value)
+	}
+	return Some(values)
+}
+
+// SequenceSeq is like Sequence for an iterator. It stops at the first None.
+func SequenceSeq[T any](seq iter.Seq[Option[T]]) Option[[]T] {
+	values := []T{}
+	for o := range seq {
+		if o.IsNone() {
+			return None[[]T]()
+		}
+		values = append(values, *o.value)
+	}
+	return Some(values)
+}
+
+// Traverse applies f to every element and returns Some of the results if all are Some, otherwise returns None.
+// f is not called for the elements after the first None.
+func Traverse[T, U any](elements []T, f func(T) Option[U]) Option[[]U] {
+	values := make([]U, 0, len(elements))
+	for _, element := range elements {
+		o := f(element)
+		if o.IsNone() {
+			return None[[]U]()
+		}
+		values = append(values, *o.value)
+	}
+	return Some(values)
+}
+
+// TraverseSeq is like Traverse for an iterator. It stops at the first None.
+func TraverseSeq[T, U any](seq iter.Seq[T], f func(T) Option[U]) Option[[]U] {
+	return SequenceSeq(func(yield func(Option[U]) bool) {
+		for element := range seq {
+			if !yield(f(element)) {
+				return
+			}
+		}
+	})
+}
+
+// Compact returns the contained values of the Options that are Some, dropping the Nones.
+func Compact[T any](opts []Option[T]) []T {
+	values := make([]T, 0, len(opts))
+	for _, o := range opts {
+		if o.IsSome() {
+			values = append(values, *o.value)
+		}
+	}
+	return values
+}
+
+// CompactSeq returns an iterator over the contained values of the Options that are Some.
+func CompactSeq[T any](seq iter.Seq[Option[T]]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for o := range seq {
+			if o.IsSome() && !yield(*o.value) {
+				return
+			}
+		}
+	}
+}
+
+// FirstSome returns the first Option that is Some, or None if there is none.
+func FirstSome[T any](opts ...Option[T]) Option[T] {
+	for _, o := range opts {
+		if o.IsSome() {
+			return o
+		}
+	}
+	return None[T]()
+}
+
+// FirstSomeSeq is like FirstSome for an iterator. It stops at the first Some.
+func FirstSomeSeq[T any](seq iter.Seq[Option[T]]) Option[T] {
+	for o := range seq {
+		if o.IsSome() {
+			return o
+		}
+	}
+	return None[T]()
+}
+
+// FromMap returns Some(m[key]) if key is in m, otherwise returns None.
+func FromMap[K comparable, V any](m map[K]V, key K) Option[V] {
+	value, exists := m[key]
+	if !exists {
+		return None[V]()
+	}
+	return Some(value)
+}
+
+// FromIndex returns Some(s[i]) if i is a valid index of s, otherwise returns None.
+func FromIndex[T any](s []T, i int) Option[T] {
+	if i < 0 || i >= len(s) {
+		return None[T]()
+	}
+	return Some(s[i])
+}
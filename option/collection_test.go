@@ -0,0 +1,153 @@
+package option_test
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/gosuda/stdx/option"
+)
+
+func parse(s string) option.Option[int] {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return option.None[int]()
+	}
+	return option.Some(n)
+}
+
+func TestSequence(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []option.Option[int]
+		want option.Option[[]int]
+	}{
+		{"AllSome", []option.Option[int]{option.Some(1), option.Some(2)}, option.Some([]int{1, 2})},
+		{"OneNone", []option.Option[int]{option.Some(1), option.None[int]()}, option.None[[]int]()},
+		{"Empty", nil, option.Some([]int{})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := option.Sequence(tt.opts)
+			if got.IsSome() != tt.want.IsSome() || !slices.Equal(got.UnwrapOr(nil), tt.want.UnwrapOr(nil)) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+			got = option.SequenceSeq(slices.Values(tt.opts))
+			if got.IsSome() != tt.want.IsSome() || !slices.Equal(got.UnwrapOr(nil), tt.want.UnwrapOr(nil)) {
+				t.Errorf("SequenceSeq: expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+	if got := option.Sequence[int](nil); got.Unwrap() == nil {
+		t.Error("Expected Sequence of no Options to be Some of a non-nil slice")
+	}
+}
+
+func TestTraverse(t *testing.T) {
+	if got := option.Traverse([]string{"1", "2", "3"}, parse); !slices.Equal(got.UnwrapOr(nil), []int{1, 2, 3}) {
+		t.Errorf("Expected Some([1 2 3]), got %v", got)
+	}
+
+	var calls []string
+	counting := func(s string) option.Option[int] {
+		calls = append(calls, s)
+		return parse(s)
+	}
+	if got := option.Traverse([]string{"1", "x", "3"}, counting); got.IsSome() {
+		t.Errorf("Expected None, got %v", got)
+	}
+	if !slices.Equal(calls, []string{"1", "x"}) {
+		t.Errorf("Expected Traverse to stop at the first None, called with %v", calls)
+	}
+
+	calls = nil
+	if got := option.TraverseSeq(slices.Values([]string{"1", "x", "3"}), counting); got.IsSome() {
+		t.Errorf("Expected None, got %v", got)
+	}
+	if !slices.Equal(calls, []string{"1", "x"}) {
+		t.Errorf("Expected TraverseSeq to stop at the first None, called with %v", calls)
+	}
+	if got := option.TraverseSeq(slices.Values([]string{"4", "5"}), parse); !slices.Equal(got.UnwrapOr(nil), []int{4, 5}) {
+		t.Errorf("Expected Some([4 5]), got %v", got)
+	}
+}
+
+func TestCompact(t *testing.T) {
+	opts := []option.Option[int]{option.None[int](), option.Some(1), option.None[int](), option.Some(2)}
+
+	if got := option.Compact(opts); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("Expected [1 2], got %v", got)
+	}
+	if got := slices.Collect(option.CompactSeq(slices.Values(opts))); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("Expected [1 2], got %v", got)
+	}
+	for v := range option.CompactSeq(slices.Values(opts)) {
+		if v != 1 {
+			t.Errorf("Expected early break after 1, got %d", v)
+		}
+		break
+	}
+}
+
+func TestFirstSome(t *testing.T) {
+	none := option.None[int]()
+
+	if got := option.FirstSome(none, option.Some(1), option.Some(2)); got.UnwrapOr(0) != 1 {
+		t.Errorf("Expected Some(1), got %v", got)
+	}
+	if got := option.FirstSome(none, none); got.IsSome() {
+		t.Errorf("Expected None, got %v", got)
+	}
+	if got := option.FirstSome[int](); got.IsSome() {
+		t.Errorf("Expected None, got %v", got)
+	}
+
+	pulled := 0
+	seq := func(yield func(option.Option[int]) bool) {
+		for _, o := range []option.Option[int]{none, option.Some(3), option.Some(4)} {
+			pulled++
+			if !yield(o) {
+				return
+			}
+		}
+	}
+	if got := option.FirstSomeSeq(seq); got.UnwrapOr(0) != 3 || pulled != 2 {
+		t.Errorf("Expected Some(3) after 2 elements, got %v after %d", got, pulled)
+	}
+}
+
+func TestFromMap(t *testing.T) {
+	m := map[string]int{"a": 1, "zero": 0}
+
+	if got := option.FromMap(m, "a"); got.UnwrapOr(-1) != 1 {
+		t.Errorf("Expected Some(1), got %v", got)
+	}
+	if got := option.FromMap(m, "zero"); got.IsNone() {
+		t.Error("Expected a present zero value to be Some")
+	}
+	if got := option.FromMap(m, "missing"); got.IsSome() {
+		t.Errorf("Expected None, got %v", got)
+	}
+	if got := option.FromMap[string, int](nil, "a"); got.IsSome() {
+		t.Errorf("Expected None for nil map, got %v", got)
+	}
+}
+
+func TestFromIndex(t *testing.T) {
+	s := []string{"a", "b"}
+
+	tests := []struct {
+		i    int
+		want option.Option[string]
+	}{
+		{0, option.Some("a")},
+		{1, option.Some("b")},
+		{2, option.None[string]()},
+		{-1, option.None[string]()},
+	}
+	for _, tt := range tests {
+		if got := option.FromIndex(s, tt.i); got.String() != tt.want.String() {
+			t.Errorf("FromIndex(%d): expected %v, got %v", tt.i, tt.want, got)
+		}
+	}
+}
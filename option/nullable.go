@@ -0,0 +1,125 @@
+package option
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// nullableState is the state of a Nullable. The zero value is absent.
+type nullableState uint8
+
+const (
+	absent nullableState = iota
+	null
+	present
+)
+
+// Nullable is a tri-state optional value that tells an absent JSON field from an explicit null,
+// for example to distinguish "don't change" from "clear" in a PATCH request.
+//
+// The zero value is Absent. UnmarshalJSON is only called for fields present in the input,
+// so a field that is missing stays Absent, null becomes Null and anything else becomes a value.
+// Tag fields with `json:",omitzero"` so that Absent is omitted again when marshaling.
+type Nullable[T any] struct {
+	state nullableState
+	value T
+}
+
+// Absent creates a Nullable that holds nothing, not even null.
+func Absent[T any]() Nullable[T] {
+	return Nullable[T]{}
+}
+
+// Null creates a Nullable that holds an explicit null.
+func Null[T any]() Nullable[T] {
+	return Nullable[T]{state: null}
+}
+
+// NullableOf creates a Nullable that holds the given value.
+func NullableOf[T any](value T) Nullable[T] {
+	return Nullable[T]{state: present, value: value}
+}
+
+// NullableFrom converts an Option into a Nullable: Some becomes a value and None becomes Null.
+func NullableFrom[T any](o Option[T]) Nullable[T] {
+	if o.IsNone() {
+		return Null[T]()
+	}
+	return NullableOf(*o.value)
+}
+
+// IsAbsent returns true if the Nullable holds nothing.
+func (n Nullable[T]) IsAbsent() bool {
+	return n.state == absent
+}
+
+// IsNull returns true if the Nullable holds an explicit null.
+func (n Nullable[T]) IsNull() bool {
+	return n.state == null
+}
+
+// HasValue returns true if the Nullable holds a value.
+func (n Nullable[T]) HasValue() bool {
+	return n.state == present
+}
+
+// IsZero returns true if the Nullable is Absent, so that fields tagged `json:",omitzero"` omit it.
+func (n Nullable[T]) IsZero() bool {
+	return n.IsAbsent()
+}
+
+// Get returns the contained value and true, or the zero value and false if the Nullable is Absent or Null.
+func (n Nullable[T]) Get() (T, bool) {
+	return n.value, n.HasValue()
+}
+
+// UnwrapOr returns the contained value or the provided default.
+func (n Nullable[T]) UnwrapOr(defaultValue T) T {
+	if !n.HasValue() {
+		return defaultValue
+	}
+	return n.value
+}
+
+// Option converts the Nullable into an Option, mapping both Absent and Null to None.
+func (n Nullable[T]) Option() Option[T] {
+	if !n.HasValue() {
+		return None[T]()
+	}
+	return Some(n.value)
+}
+
+// String implements the fmt.Stringer interface.
+func (n Nullable[T]) String() string {
+	switch n.state {
+	case absent:
+		return "Absent"
+	case null:
+		return "Null"
+	}
+	return fmt.Sprintf("Value(%v)", n.value)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Absent marshals to null unless the field is omitted with omitzero.
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+	if !n.HasValue() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.value)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*n = Null[T]()
+		return nil
+	}
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*n = NullableOf(value)
+	return nil
+}
@@ -0,0 +1,95 @@
+package option_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gosuda/stdx/option"
+)
+
+func TestNullable_States(t *testing.T) {
+	tests := []struct {
+		name                string
+		n                   option.Nullable[int]
+		absent, null, value bool
+		str                 string
+	}{
+		{"Absent", option.Absent[int](), true, false, false, "Absent"},
+		{"Zero", option.Nullable[int]{}, true, false, false, "Absent"},
+		{"Null", option.Null[int](), false, true, false, "Null"},
+		{"Value", option.NullableOf(0), false, false, true, "Value(0)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.n.IsAbsent() != tt.absent || tt.n.IsNull() != tt.null || tt.n.HasValue() != tt.value {
+				t.Errorf("Unexpected state for %v", tt.n)
+			}
+			if tt.n.IsZero() != tt.absent {
+				t.Errorf("IsZero should report Absent for %v", tt.n)
+			}
+			if tt.n.String() != tt.str {
+				t.Errorf("Expected %q, got %q", tt.str, tt.n.String())
+			}
+			if tt.n.Option().IsSome() != tt.value {
+				t.Errorf("Option should be Some only for a value, got %v", tt.n.Option())
+			}
+		})
+	}
+
+	if v, ok := option.NullableOf(5).Get(); !ok || v != 5 {
+		t.Errorf("Expected 5, true; got %d, %t", v, ok)
+	}
+	if option.Null[int]().UnwrapOr(9) != 9 || option.NullableOf(1).UnwrapOr(9) != 1 {
+		t.Error("Unexpected UnwrapOr result")
+	}
+	if !option.NullableFrom(option.None[int]()).IsNull() || option.NullableFrom(option.Some(3)).UnwrapOr(0) != 3 {
+		t.Error("NullableFrom should map None to Null and Some to a value")
+	}
+}
+
+func TestNullable_JSON(t *testing.T) {
+	type patch struct {
+		Name  option.Nullable[string] `json:"name,omitzero"`
+		Email option.Nullable[string] `json:"email,omitzero"`
+		Age   option.Nullable[int]    `json:"age,omitzero"`
+	}
+
+	tests := []struct {
+		name string
+		json string
+		want patch
+	}{
+		{"Empty", `{}`, patch{}},
+		{"Null", `{"email":null}`, patch{Email: option.Null[string]()}},
+		{"Value", `{"name":"gopher","age":0}`, patch{Name: option.NullableOf("gopher"), Age: option.NullableOf(0)}},
+		{"Mixed", `{"name":"","email":null,"age":30}`,
+			patch{Name: option.NullableOf(""), Email: option.Null[string](), Age: option.NullableOf(30)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got patch
+			if err := json.Unmarshal([]byte(tt.json), &got); err != nil {
+				t.Fatalf("Failed to unmarshal: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %+v, got %+v", tt.want, got)
+			}
+
+			data, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("Failed to marshal: %v", err)
+			}
+			if string(data) != tt.json {
+				t.Errorf("Expected round trip to %s, got %s", tt.json, data)
+			}
+		})
+	}
+
+	var p patch
+	if err := json.Unmarshal([]byte(`{"age":"old"}`), &p); err == nil {
+		t.Error("Expected error for mismatched type")
+	}
+	if data, _ := json.Marshal(option.Absent[int]()); string(data) != "null" {
+		t.Errorf("Expected Absent to marshal to null outside a struct, got %s", data)
+	}
+}
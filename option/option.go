@@ -5,6 +5,8 @@ package option
 import (
 	"encoding/json"
 	"fmt"
+
+	"github.com/gosuda/stdx/tuple"
 )
 
 // Option represents an optional value: every Option is either Some and contains a value, or None, and does not.
@@ -100,6 +102,17 @@ func (o Option[T]) OrElse(f func() Option[T]) Option[T] {
 	return f()
 }
 
+// Xor returns Some if exactly one of the Option and optb is Some, otherwise returns None.
+func (o Option[T]) Xor(optb Option[T]) Option[T] {
+	switch {
+	case o.IsSome() && optb.IsNone():
+		return o
+	case o.IsNone() && optb.IsSome():
+		return optb
+	}
+	return None[T]()
+}
+
 // And returns None if the Option is None, otherwise returns optb.
 func (o Option[T]) And(optb Option[T]) Option[T] {
 	if o.IsNone() {
@@ -113,6 +126,79 @@ func AndThen[T, U any](o Option[T], f func(T) Option[U]) Option[U] {
 	return FlatMap(o, f)
 }
 
+// IsSomeAnd returns true if the Option is Some and the contained value satisfies predicate.
+func (o Option[T]) IsSomeAnd(predicate func(T) bool) bool {
+	return o.IsSome() && predicate(*o.value)
+}
+
+// IsNoneOr returns true if the Option is None or the contained value satisfies predicate.
+func (o Option[T]) IsNoneOr(predicate func(T) bool) bool {
+	return o.IsNone() || predicate(*o.value)
+}
+
+// Inspect calls f with the contained value (if any) and returns the Option unchanged.
+func (o Option[T]) Inspect(f func(T)) Option[T] {
+	if o.IsSome() {
+		f(*o.value)
+	}
+	return o
+}
+
+// Take returns the Option and leaves None in its place.
+func (o *Option[T]) Take() Option[T] {
+	taken := *o
+	*o = None[T]()
+	return taken
+}
+
+// Replace stores Some(value) in the Option and returns the previous Option.
+func (o *Option[T]) Replace(value T) Option[T] {
+	previous := *o
+	*o = Some(value)
+	return previous
+}
+
+// GetOrInsertWith returns the contained value, first storing f() in the Option if it is None.
+// Unlike Rust, the value is returned by copy, since Options that were copied from each other share storage.
+func (o *Option[T]) GetOrInsertWith(f func() T) T {
+	if o.IsNone() {
+		*o = Some(f())
+	}
+	return *o.value
+}
+
+// Zip returns Some pair of both contained values if both Options are Some, otherwise returns None.
+func Zip[T, U any](a Option[T], b Option[U]) Option[tuple.Pair[T, U]] {
+	if a.IsNone() || b.IsNone() {
+		return None[tuple.Pair[T, U]]()
+	}
+	return Some(tuple.NewPair(*a.value, *b.value))
+}
+
+// ZipWith returns Some(f(a, b)) if both Options are Some, otherwise returns None.
+func ZipWith[T, U, R any](a Option[T], b Option[U], f func(T, U) R) Option[R] {
+	if a.IsNone() || b.IsNone() {
+		return None[R]()
+	}
+	return Some(f(*a.value, *b.value))
+}
+
+// Unzip splits an Option of a pair into a pair of Options, both Some or both None.
+func Unzip[T, U any](o Option[tuple.Pair[T, U]]) (Option[T], Option[U]) {
+	if o.IsNone() {
+		return None[T](), None[U]()
+	}
+	return Some(o.value.First()), Some(o.value.Second())
+}
+
+// Flatten removes one level of nesting from an Option of an Option.
+func Flatten[T any](o Option[Option[T]]) Option[T] {
+	if o.IsNone() {
+		return None[T]()
+	}
+	return *o.value
+}
+
 // Match pattern matches on the Option value.
 func (o Option[T]) Match(some func(T), none func()) {
 	if o.IsSome() {
@@ -298,3 +298,112 @@ func TestOption_ToPtr(t *testing.T) {
 		t.Error("Expected None.ToPtr() to return nil")
 	}
 }
+
+func TestOption_Xor(t *testing.T) {
+	some := option.Some(1)
+	other := option.Some(2)
+	none := option.None[int]()
+
+	if some.Xor(none).Unwrap() != 1 || none.Xor(other).Unwrap() != 2 {
+		t.Error("Expected Xor with exactly one Some to return it")
+	}
+	if some.Xor(other).IsSome() || none.Xor(none).IsSome() {
+		t.Error("Expected Xor of two Somes or two Nones to be None")
+	}
+}
+
+func TestOption_IsSomeAnd_IsNoneOr(t *testing.T) {
+	positive := func(x int) bool { return x > 0 }
+
+	if !option.Some(1).IsSomeAnd(positive) || option.Some(-1).IsSomeAnd(positive) || option.None[int]().IsSomeAnd(positive) {
+		t.Error("Unexpected IsSomeAnd result")
+	}
+	if !option.Some(1).IsNoneOr(positive) || option.Some(-1).IsNoneOr(positive) || !option.None[int]().IsNoneOr(positive) {
+		t.Error("Unexpected IsNoneOr result")
+	}
+}
+
+func TestOption_Inspect(t *testing.T) {
+	var seen []int
+	record := func(x int) { seen = append(seen, x) }
+
+	if option.Some(1).Inspect(record).Unwrap() != 1 || option.None[int]().Inspect(record).IsSome() {
+		t.Error("Expected Inspect to return the Option unchanged")
+	}
+	if len(seen) != 1 || seen[0] != 1 {
+		t.Errorf("Expected Inspect to be called only for Some, got %v", seen)
+	}
+}
+
+func TestOption_Take_Replace(t *testing.T) {
+	opt := option.Some(1)
+
+	taken := opt.Take()
+	if taken.Unwrap() != 1 || opt.IsSome() {
+		t.Error("Expected Take to return the value and leave None")
+	}
+	if opt.Take().IsSome() {
+		t.Error("Expected Take on None to return None")
+	}
+
+	previous := opt.Replace(2)
+	if previous.IsSome() || opt.Unwrap() != 2 {
+		t.Error("Expected Replace on None to store the value and return None")
+	}
+	previous = opt.Replace(3)
+	if previous.Unwrap() != 2 || opt.Unwrap() != 3 {
+		t.Error("Expected Replace to return the previous value")
+	}
+}
+
+func TestOption_GetOrInsertWith(t *testing.T) {
+	calls := 0
+	f := func() int {
+		calls++
+		return 7
+	}
+
+	var opt option.Option[int]
+	if opt.GetOrInsertWith(f) != 7 || opt.Unwrap() != 7 {
+		t.Error("Expected GetOrInsertWith on None to insert the computed value")
+	}
+	if opt.GetOrInsertWith(f) != 7 || calls != 1 {
+		t.Errorf("Expected f to be called once, got %d calls", calls)
+	}
+}
+
+func TestOption_Zip(t *testing.T) {
+	zipped := option.Zip(option.Some(1), option.Some("a"))
+	if zipped.IsNone() || zipped.Unwrap().First() != 1 || zipped.Unwrap().Second() != "a" {
+		t.Errorf("Expected Some((1, a)), got %v", zipped)
+	}
+	if option.Zip(option.Some(1), option.None[string]()).IsSome() || option.Zip(option.None[int](), option.Some("a")).IsSome() {
+		t.Error("Expected Zip with None to be None")
+	}
+
+	sum := option.ZipWith(option.Some(2), option.Some(3), func(a, b int) int { return a + b })
+	if sum.Unwrap() != 5 {
+		t.Errorf("Expected Some(5), got %v", sum)
+	}
+	if option.ZipWith(option.None[int](), option.Some(3), func(a, b int) int { return a + b }).IsSome() {
+		t.Error("Expected ZipWith with None to be None")
+	}
+
+	a, b := option.Unzip(zipped)
+	if a.Unwrap() != 1 || b.Unwrap() != "a" {
+		t.Errorf("Expected Some(1), Some(a); got %v, %v", a, b)
+	}
+	a, b = option.Unzip(option.Zip(option.None[int](), option.Some("a")))
+	if a.IsSome() || b.IsSome() {
+		t.Error("Expected Unzip of None to give two Nones")
+	}
+}
+
+func TestOption_Flatten(t *testing.T) {
+	if option.Flatten(option.Some(option.Some(1))).Unwrap() != 1 {
+		t.Error("Expected Some(Some(1)) to flatten to Some(1)")
+	}
+	if option.Flatten(option.Some(option.None[int]())).IsSome() || option.Flatten(option.None[option.Option[int]]()).IsSome() {
+		t.Error("Expected Some(None) and None to flatten to None")
+	}
+}
@@ -0,0 +1,30 @@
+package option
+
+import (
+	"database/sql"
+	"database/sql/driver"
+)
+
+// Scan implements the sql.Scanner interface. A NULL column scans to None; any other value is
+// converted to T with the same rules as Rows.Scan and wrapped in Some.
+func (o *Option[T]) Scan(src any) error {
+	var n sql.Null[T]
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	if n.Valid {
+		*o = Some(n.V)
+	} else {
+		*o = None[T]()
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface. None is stored as NULL; the contained value is
+// converted with driver.DefaultParameterConverter, which honors a driver.Valuer implemented by T.
+func (o Option[T]) Value() (driver.Value, error) {
+	if o.IsNone() {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(*o.value)
+}
@@ -0,0 +1,155 @@
+package option_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gosuda/stdx/option"
+)
+
+// fakeDriver is a minimal database/sql driver. Exec records its arguments and Query returns
+// a single row holding the values of the last Exec.
+type fakeDriver struct {
+	args []driver.Value
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return &fakeConn{d}, nil }
+
+type fakeConn struct{ d *fakeDriver }
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return &fakeStmt{c.d}, nil }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+type fakeStmt struct{ d *fakeDriver }
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.args = slices.Clone(args)
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeRows{values: s.d.args}, nil
+}
+
+type fakeRows struct {
+	values []driver.Value
+	done   bool
+}
+
+func (r *fakeRows) Columns() []string { return make([]string, len(r.values)) }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	copy(dest, r.values)
+	return nil
+}
+
+var fake = &fakeDriver{}
+
+func init() {
+	sql.Register("option-fake", fake)
+}
+
+func openFake(t *testing.T) *sql.DB {
+	db, err := sql.Open("option-fake", "")
+	if err != nil {
+		t.Fatalf("Failed to open fake database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+type celsius float64
+
+func TestOption_SQLValue(t *testing.T) {
+	db := openFake(t)
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		arg  any
+		want driver.Value
+	}{
+		{"None", option.None[int](), nil},
+		{"Int", option.Some(42), int64(42)},
+		{"Uint8", option.Some(uint8(7)), int64(7)},
+		{"String", option.Some("hello"), "hello"},
+		{"EmptyString", option.Some(""), ""},
+		{"Bool", option.Some(true), true},
+		{"NamedFloat", option.Some(celsius(21.5)), 21.5},
+		{"Time", option.Some(now), now},
+		{"Valuer", option.Some(sql.NullString{String: "inner", Valid: true}), "inner"},
+		{"NestedNone", option.Some(option.None[string]()), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := db.Exec("INSERT", tt.arg); err != nil {
+				t.Fatalf("Exec failed: %v", err)
+			}
+			if len(fake.args) != 1 || fake.args[0] != tt.want {
+				t.Errorf("Expected driver value %#v, got %#v", tt.want, fake.args)
+			}
+		})
+	}
+}
+
+func TestOption_SQLScan(t *testing.T) {
+	db := openFake(t)
+
+	scan := func(t *testing.T, value driver.Value, dest any) error {
+		t.Helper()
+		fake.args = []driver.Value{value}
+		return db.QueryRow("SELECT").Scan(dest)
+	}
+
+	t.Run("NullInt", func(t *testing.T) {
+		o := option.Some(1)
+		if err := scan(t, nil, &o); err != nil || o.IsSome() {
+			t.Errorf("Expected None, got %v (%v)", o, err)
+		}
+	})
+	t.Run("Int", func(t *testing.T) {
+		var o option.Option[int]
+		if err := scan(t, int64(42), &o); err != nil || o.UnwrapOr(0) != 42 {
+			t.Errorf("Expected Some(42), got %v (%v)", o, err)
+		}
+	})
+	t.Run("StringFromBytes", func(t *testing.T) {
+		var o option.Option[string]
+		if err := scan(t, []byte("abc"), &o); err != nil || o.UnwrapOr("") != "abc" {
+			t.Errorf("Expected Some(abc), got %v (%v)", o, err)
+		}
+	})
+	t.Run("IntFromString", func(t *testing.T) {
+		var o option.Option[int64]
+		if err := scan(t, "17", &o); err != nil || o.UnwrapOr(0) != 17 {
+			t.Errorf("Expected Some(17), got %v (%v)", o, err)
+		}
+	})
+	t.Run("Time", func(t *testing.T) {
+		now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		var o option.Option[time.Time]
+		if err := scan(t, now, &o); err != nil || !o.UnwrapOr(time.Time{}).Equal(now) {
+			t.Errorf("Expected Some(%v), got %v (%v)", now, o, err)
+		}
+	})
+	t.Run("ConversionError", func(t *testing.T) {
+		var o option.Option[int]
+		if err := scan(t, "not a number", &o); err == nil {
+			t.Errorf("Expected conversion error, got %v", o)
+		}
+	})
+}
@@ -0,0 +1,159 @@
+package option
+
+import (
+	"encoding"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// IsZero reports whether the Option is None, so that fields tagged `json:",omitzero"` omit None.
+func (o Option[T]) IsZero() bool {
+	return o.IsNone()
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// None marshals to empty text. The contained value must implement encoding.TextMarshaler
+// or have a string, boolean or numeric underlying type.
+func (o Option[T]) MarshalText() ([]byte, error) {
+	if o.IsNone() {
+		return []byte{}, nil
+	}
+	return formatText(*o.value)
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// Empty text unmarshals to None, so Some("") does not survive a round trip through text.
+func (o *Option[T]) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*o = None[T]()
+		return nil
+	}
+	return o.Set(string(text))
+}
+
+// Set implements the flag.Value interface. Setting a flag always yields Some, even for an empty string.
+// The help text shows the default through String, so an unset flag defaults to None.
+func (o *Option[T]) Set(s string) error {
+	var value T
+	if err := parseText(s, &value); err != nil {
+		return err
+	}
+	*o = Some(value)
+	return nil
+}
+
+// IsBoolFlag lets an Option[bool] flag be set without a value, as in -verbose.
+func (o *Option[T]) IsBoolFlag() bool {
+	return reflect.TypeFor[T]().Kind() == reflect.Bool
+}
+
+// MarshalXML implements the xml.Marshaler interface. None omits the element entirely.
+func (o Option[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if o.IsNone() {
+		return nil
+	}
+	return e.EncodeElement(*o.value, start)
+}
+
+// xmlSchemaInstance is the namespace of the xsi:nil attribute.
+const xmlSchemaInstance = "http://www.w3.org/2001/XMLSchema-instance"
+
+// UnmarshalXML implements the xml.Unmarshaler interface. A present element unmarshals to Some,
+// unless it carries xsi:nil="true" or "1", which unmarshals to None. A missing element leaves the Option unchanged.
+func (o *Option[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		if attr.Name.Space == xmlSchemaInstance && attr.Name.Local == "nil" &&
+			(attr.Value == "true" || attr.Value == "1") {
+			*o = None[T]()
+			return d.Skip()
+		}
+	}
+	var value T
+	if err := d.DecodeElement(&value, &start); err != nil {
+		return err
+	}
+	*o = Some(value)
+	return nil
+}
+
+// MarshalXMLAttr implements the xml.MarshalerAttr interface. None omits the attribute.
+func (o Option[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if o.IsNone() {
+		return xml.Attr{}, nil
+	}
+	text, err := formatText(*o.value)
+	if err != nil {
+		return xml.Attr{}, err
+	}
+	return xml.Attr{Name: name, Value: string(text)}, nil
+}
+
+// UnmarshalXMLAttr implements the xml.UnmarshalerAttr interface. A present attribute unmarshals to Some.
+func (o *Option[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return o.Set(attr.Value)
+}
+
+// formatText converts value to text using encoding.TextMarshaler or its underlying kind.
+func formatText(value any) ([]byte, error) {
+	if m, ok := value.(encoding.TextMarshaler); ok {
+		return m.MarshalText()
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String:
+		return []byte(v.String()), nil
+	case reflect.Bool:
+		return strconv.AppendBool(nil, v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(nil, v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.AppendUint(nil, v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.AppendFloat(nil, v.Float(), 'g', -1, v.Type().Bits()), nil
+	}
+	return nil, fmt.Errorf("option: cannot marshal %T as text", value)
+}
+
+// parseText parses s into *ptr using encoding.TextUnmarshaler or the underlying kind of T.
+func parseText[T any](s string, ptr *T) error {
+	if u, ok := any(ptr).(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+	v := reflect.ValueOf(ptr).Elem()
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+		return nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+		return nil
+	}
+	return fmt.Errorf("option: cannot unmarshal text into %T", *ptr)
+}
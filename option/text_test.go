@@ -0,0 +1,179 @@
+package option_test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"flag"
+	"io"
+	"net/netip"
+	"testing"
+
+	"github.com/gosuda/stdx/option"
+)
+
+func TestOption_IsZero(t *testing.T) {
+	type config struct {
+		Port option.Option[int] `json:"port,omitzero"`
+		Host option.Option[string]
+	}
+
+	data, err := json.Marshal(config{})
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	if string(data) != `{"Host":null}` {
+		t.Errorf("Expected omitzero to omit None, got %s", data)
+	}
+
+	data, err = json.Marshal(config{Port: option.Some(0)})
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	if string(data) != `{"port":0,"Host":null}` {
+		t.Errorf("Expected Some(0) to be kept, got %s", data)
+	}
+}
+
+func TestOption_Text(t *testing.T) {
+	addr := netip.MustParseAddr("10.0.0.1")
+
+	tests := []struct {
+		name string
+		opt  interface {
+			MarshalText() ([]byte, error)
+		}
+		text string
+	}{
+		{"None", option.None[int](), ""},
+		{"Int", option.Some(-42), "-42"},
+		{"Uint", option.Some(uint16(65535)), "65535"},
+		{"Float", option.Some(1.5), "1.5"},
+		{"Bool", option.Some(true), "true"},
+		{"String", option.Some("hello"), "hello"},
+		{"Named", option.Some(celsius(-3.25)), "-3.25"},
+		{"TextMarshaler", option.Some(addr), "10.0.0.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, err := tt.opt.MarshalText()
+			if err != nil || string(text) != tt.text {
+				t.Errorf("Expected %q, got %q (%v)", tt.text, text, err)
+			}
+		})
+	}
+
+	if _, err := option.Some([]int{1}).MarshalText(); err == nil {
+		t.Error("Expected error marshaling a slice as text")
+	}
+}
+
+func TestOption_UnmarshalText(t *testing.T) {
+	var n option.Option[int]
+	if err := n.UnmarshalText([]byte("123")); err != nil || n.UnwrapOr(0) != 123 {
+		t.Errorf("Expected Some(123), got %v (%v)", n, err)
+	}
+	if err := n.UnmarshalText(nil); err != nil || n.IsSome() {
+		t.Errorf("Expected empty text to unmarshal to None, got %v (%v)", n, err)
+	}
+	if err := n.UnmarshalText([]byte("x")); err == nil {
+		t.Error("Expected parse error")
+	}
+	var small option.Option[int8]
+	if err := small.UnmarshalText([]byte("300")); err == nil {
+		t.Error("Expected range error for int8")
+	}
+
+	var addr option.Option[netip.Addr]
+	if err := addr.UnmarshalText([]byte("::1")); err != nil || addr.Unwrap() != netip.IPv6Loopback() {
+		t.Errorf("Expected Some(::1), got %v (%v)", addr, err)
+	}
+}
+
+func TestOption_Flag(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	var port option.Option[int]
+	var name option.Option[string]
+	var verbose option.Option[bool]
+	var timeout option.Option[float64]
+	fs.Var(&port, "port", "listen port")
+	fs.Var(&name, "name", "name")
+	fs.Var(&verbose, "verbose", "verbose output")
+	fs.Var(&timeout, "timeout", "timeout")
+
+	if err := fs.Parse([]string{"-port", "8080", "-name=", "-verbose"}); err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if port.UnwrapOr(0) != 8080 {
+		t.Errorf("Expected port Some(8080), got %v", port)
+	}
+	if name.IsNone() || name.Unwrap() != "" {
+		t.Errorf("Expected an explicitly empty flag to be Some(\"\"), got %v", name)
+	}
+	if !verbose.UnwrapOr(false) {
+		t.Errorf("Expected bool flag without value to be Some(true), got %v", verbose)
+	}
+	if timeout.IsSome() {
+		t.Errorf("Expected unset flag to be None, got %v", timeout)
+	}
+
+	if err := fs.Parse([]string{"-port", "http"}); err == nil {
+		t.Error("Expected error for invalid flag value")
+	}
+}
+
+func TestOption_XML(t *testing.T) {
+	type server struct {
+		XMLName xml.Name              `xml:"server"`
+		ID      option.Option[int]    `xml:"id,attr"`
+		Name    option.Option[string] `xml:"name"`
+		Port    option.Option[int]    `xml:"port"`
+	}
+
+	tests := []struct {
+		name string
+		in   server
+		xml  string
+	}{
+		{"AllNone", server{}, `<server></server>`},
+		{"AllSome", server{ID: option.Some(7), Name: option.Some("web"), Port: option.Some(80)},
+			`<server id="7"><name>web</name><port>80</port></server>`},
+		{"EmptyString", server{Name: option.Some("")}, `<server><name></name></server>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := xml.Marshal(tt.in)
+			if err != nil || string(data) != tt.xml {
+				t.Fatalf("Expected %s, got %s (%v)", tt.xml, data, err)
+			}
+
+			var out server
+			if err := xml.Unmarshal(data, &out); err != nil {
+				t.Fatalf("Failed to unmarshal: %v", err)
+			}
+			if out.ID.String() != tt.in.ID.String() || out.Name.String() != tt.in.Name.String() ||
+				out.Port.String() != tt.in.Port.String() {
+				t.Errorf("Round trip mismatch: expected %+v, got %+v", tt.in, out)
+			}
+		})
+	}
+
+	var out server
+	data := `<server xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"><name xsi:nil="true"/><port>x</port></server>`
+	if err := xml.Unmarshal([]byte(data), &out); err == nil {
+		t.Error("Expected error for invalid port")
+	}
+	if out.Name.IsSome() {
+		t.Errorf("Expected xsi:nil element to unmarshal to None, got %v", out.Name)
+	}
+
+	out = server{}
+	data = `<server xmlns:foo="urn:foo"><name foo:nil="true">bob</name></server>`
+	if err := xml.Unmarshal([]byte(data), &out); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+	if out.Name.UnwrapOr("") != "bob" {
+		t.Errorf("Expected a nil attribute outside the xsi namespace to be ignored, got %v", out.Name)
+	}
+}
@@ -0,0 +1,74 @@
+package result
+
+import (
+	"errors"
+	"iter"
+)
+
+// Collect returns Ok of all contained values if every Result is Ok, otherwise returns the first Err.
+// An empty slice yields Ok of an empty slice.
+func Collect[T, E any](results []Result[T, E]) Result[[]T, E] {
+	values := make([]T, 0, len(results))
+	for _, r := range results {
+		if r.IsErr() {
+			return Err[[]T, E](*r.err)
+		}
+		values = append(values, *r.value)
+	}
+	return Ok[[]T, E](values)
+}
+
+// CollectAll is like Collect but reports every error, joined with errors.Join in order.
+func CollectAll[T any](results []Result[T, error]) Result[[]T, error] {
+	values, errs := Partition(results)
+	if len(errs) > 0 {
+		return Err[[]T, error](errors.Join(errs...))
+	}
+	return Ok[[]T, error](values)
+}
+
+// Partition splits results into the values of the Oks and the errors of the Errs, keeping their order.
+func Partition[T, E any](results []Result[T, E]) ([]T, []E) {
+	var values []T
+	var errs []E
+	for _, r := range results {
+		if r.IsOk() {
+			values = append(values, *r.value)
+		} else {
+			errs = append(errs, *r.err)
+		}
+	}
+	return values, errs
+}
+
+// Traverse applies f to every element of seq and returns Ok of the results if all are Ok,
+// otherwise returns the first Err. It stops iterating seq at the first Err.
+func Traverse[T, U, E any](seq iter.Seq[T], f func(T) Result[U, E]) Result[[]U, E] {
+	values := []U{}
+	for element := range seq {
+		r := f(element)
+		if r.IsErr() {
+			return Err[[]U, E](*r.err)
+		}
+		values = append(values, *r.value)
+	}
+	return Ok[[]U, E](values)
+}
+
+// Iter adapts an iterator of Results for use in a range loop over (value, error) pairs.
+// Each Ok yields its value with a nil error; the first Err yields the zero value with its error
+// and ends the iteration.
+func Iter[T any](seq iter.Seq[Result[T, error]]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for r := range seq {
+			if r.IsErr() {
+				var zero T
+				yield(zero, *r.err)
+				return
+			}
+			if !yield(*r.value, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package result_test
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/gosuda/stdx/result"
+)
+
+func atoi(s string) result.Result[int, error] {
+	return result.Try(strconv.Atoi(s))
+}
+
+func TestCollect(t *testing.T) {
+	errFirst := errors.New("first")
+	errSecond := errors.New("second")
+
+	tests := []struct {
+		name    string
+		results []result.Result[int, error]
+		want    []int
+		err     error
+	}{
+		{"AllOk", []result.Result[int, error]{result.Ok[int, error](1), result.Ok[int, error](2)}, []int{1, 2}, nil},
+		{"FirstErrorWins", []result.Result[int, error]{
+			result.Ok[int, error](1), result.Err[int, error](errFirst), result.Err[int, error](errSecond),
+		}, nil, errFirst},
+		{"Empty", nil, []int{}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := result.Collect(tt.results)
+			if tt.err != nil {
+				if got.IsOk() || got.UnwrapErr() != tt.err {
+					t.Errorf("Expected Err(%v), got %v", tt.err, got)
+				}
+				return
+			}
+			if got.IsErr() || !slices.Equal(got.Unwrap(), tt.want) || got.Unwrap() == nil {
+				t.Errorf("Expected Ok(%v), got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCollectAll(t *testing.T) {
+	errFirst := errors.New("first")
+	errSecond := errors.New("second")
+
+	got := result.CollectAll([]result.Result[int, error]{
+		result.Err[int, error](errFirst), result.Ok[int, error](1), result.Err[int, error](errSecond),
+	})
+	if got.IsOk() {
+		t.Fatalf("Expected Err, got %v", got)
+	}
+	err := got.UnwrapErr()
+	if !errors.Is(err, errFirst) || !errors.Is(err, errSecond) || err.Error() != "first\nsecond" {
+		t.Errorf("Expected both errors joined in order, got %q", err)
+	}
+
+	ok := result.CollectAll([]result.Result[int, error]{result.Ok[int, error](1), result.Ok[int, error](2)})
+	if ok.IsErr() || !slices.Equal(ok.Unwrap(), []int{1, 2}) {
+		t.Errorf("Expected Ok([1 2]), got %v", ok)
+	}
+}
+
+func TestPartition(t *testing.T) {
+	values, errs := result.Partition([]result.Result[int, string]{
+		result.Ok[int, string](1), result.Err[int, string]("a"), result.Ok[int, string](2), result.Err[int, string]("b"),
+	})
+	if !slices.Equal(values, []int{1, 2}) || !slices.Equal(errs, []string{"a", "b"}) {
+		t.Errorf("Expected [1 2] and [a b], got %v and %v", values, errs)
+	}
+
+	values, errs = result.Partition[int, string](nil)
+	if len(values) != 0 || len(errs) != 0 {
+		t.Errorf("Expected nothing for no results, got %v and %v", values, errs)
+	}
+}
+
+func TestTraverse(t *testing.T) {
+	got := result.Traverse(slices.Values([]string{"1", "2", "3"}), atoi)
+	if got.IsErr() || !slices.Equal(got.Unwrap(), []int{1, 2, 3}) {
+		t.Errorf("Expected Ok([1 2 3]), got %v", got)
+	}
+
+	var calls []string
+	got = result.Traverse(slices.Values([]string{"1", "x", "3"}), func(s string) result.Result[int, error] {
+		calls = append(calls, s)
+		return atoi(s)
+	})
+	if got.IsOk() {
+		t.Errorf("Expected Err, got %v", got)
+	}
+	if !slices.Equal(calls, []string{"1", "x"}) {
+		t.Errorf("Expected Traverse to stop at the first Err, called with %v", calls)
+	}
+}
+
+func TestIter(t *testing.T) {
+	errStop := errors.New("stop")
+	results := []result.Result[int, error]{
+		result.Ok[int, error](1), result.Ok[int, error](2), result.Err[int, error](errStop), result.Ok[int, error](3),
+	}
+
+	var values []int
+	var gotErr error
+	for v, err := range result.Iter(slices.Values(results)) {
+		if err != nil {
+			gotErr = err
+			break
+		}
+		values = append(values, v)
+	}
+	if !slices.Equal(values, []int{1, 2}) || gotErr != errStop {
+		t.Errorf("Expected [1 2] then %v, got %v then %v", errStop, values, gotErr)
+	}
+
+	count := 0
+	for range result.Iter(slices.Values(results)) {
+		count++
+	}
+	if count != 3 {
+		t.Errorf("Expected iteration to end after the first Err, got %d pairs", count)
+	}
+
+	for v := range result.Iter(slices.Values(results)) {
+		if v != 1 {
+			t.Errorf("Expected early break after 1, got %d", v)
+		}
+		break
+	}
+}
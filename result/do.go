@@ -0,0 +1,63 @@
+package result
+
+// Scope is the handle through which Get and Check return early from the function passed to Do.
+// It is only valid during that call and on the goroutine that runs it.
+type Scope struct {
+	done bool
+}
+
+// abort is the panic value Get and Check use to unwind to the Do that owns scope.
+type abort struct {
+	scope *Scope
+	err   error
+}
+
+// Do calls fn and returns Ok of its return value, or Err of the first error passed to Get or Check,
+// which stop fn right away. This flattens chains of FlatMap over different types into straight-line code:
+//
+//	r := result.Do(func(s *result.Scope) Order {
+//		user := result.Get(s, loadUser(id))
+//		cart := result.Get(s, loadCart(user))
+//		return result.Get(s, checkout(cart))
+//	})
+//
+// Early return is implemented with panic and recover. Panics other than those raised by Get and Check
+// for this Do, including ones from nested calls to Do, are re-panicked with the same value.
+// The panic makes the Err path several times slower than FlatMap, so prefer FlatMap in hot loops.
+func Do[T any](fn func(s *Scope) T) (r Result[T, error]) {
+	s := &Scope{}
+	defer func() {
+		s.done = true
+		if v := recover(); v != nil {
+			if a, ok := v.(*abort); ok && a.scope == s {
+				r = Err[T, error](a.err)
+				return
+			}
+			panic(v)
+		}
+	}()
+	return Ok[T, error](fn(s))
+}
+
+// Get returns the value of r, or stops the function passed to the Do that owns s with the error of r.
+func Get[T any](s *Scope, r Result[T, error]) T {
+	if r.IsErr() {
+		s.abort(*r.err)
+	}
+	return *r.value
+}
+
+// Check stops the function passed to the Do that owns s with err if err is not nil.
+func Check(s *Scope, err error) {
+	if err != nil {
+		s.abort(err)
+	}
+}
+
+// abort unwinds to the Do that owns s. It panics with a plain message if that Do has already returned.
+func (s *Scope) abort(err error) {
+	if s.done {
+		panic("result: Scope used after its Do returned")
+	}
+	panic(&abort{scope: s, err: err})
+}
@@ -0,0 +1,168 @@
+package result_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/gosuda/stdx/result"
+)
+
+var errBoomDo = errors.New("boom")
+
+func half(n int) result.Result[int, error] {
+	if n%2 != 0 {
+		return result.Err[int, error](errors.New("odd number"))
+	}
+	return result.Ok[int, error](n / 2)
+}
+
+func TestDo(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		r := result.Do(func(s *result.Scope) string {
+			n := result.Get(s, atoi("40"))
+			h := result.Get(s, half(n))
+			result.Check(s, nil)
+			return strconv.Itoa(h)
+		})
+		if !r.IsOk() || r.Unwrap() != "20" {
+			t.Errorf("Expected Ok(20), got %v", r)
+		}
+	})
+
+	t.Run("ShortCircuit", func(t *testing.T) {
+		reached := false
+		r := result.Do(func(s *result.Scope) int {
+			n := result.Get(s, atoi("7"))
+			h := result.Get(s, half(n))
+			reached = true
+			return h
+		})
+		if r.IsOk() || r.UnwrapErr().Error() != "odd number" {
+			t.Errorf("Expected Err(odd number), got %v", r)
+		}
+		if reached {
+			t.Error("Expected Get to stop the function at the first Err")
+		}
+	})
+
+	t.Run("Check", func(t *testing.T) {
+		errCheck := errors.New("check failed")
+		r := result.Do(func(s *result.Scope) int {
+			result.Check(s, errCheck)
+			return 1
+		})
+		if !result.ErrIs(r, errCheck) {
+			t.Errorf("Expected Err(check failed), got %v", r)
+		}
+	})
+
+	t.Run("Nested", func(t *testing.T) {
+		errInner := errors.New("inner")
+		r := result.Do(func(outer *result.Scope) int {
+			inner := result.Do(func(s *result.Scope) int {
+				return result.Get(s, result.Err[int, error](errInner))
+			})
+			if !result.ErrIs(inner, errInner) {
+				t.Errorf("Expected the inner Do to catch its own Err, got %v", inner)
+			}
+			return result.Get(outer, inner.Or(result.Ok[int, error](5)))
+		})
+		if r.UnwrapOr(0) != 5 {
+			t.Errorf("Expected Ok(5), got %v", r)
+		}
+
+		errOuter := errors.New("outer")
+		reached := false
+		r = result.Do(func(outer *result.Scope) int {
+			result.Do(func(*result.Scope) int {
+				return result.Get(outer, result.Err[int, error](errOuter))
+			})
+			reached = true
+			return 0
+		})
+		if !result.ErrIs(r, errOuter) || reached {
+			t.Errorf("Expected Get on the outer Scope to unwind through the inner Do, got %v", r)
+		}
+	})
+}
+
+func TestDo_ForeignPanics(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(s *result.Scope) int
+		want any
+	}{
+		{"String", func(*result.Scope) int { panic("foreign") }, "foreign"},
+		{"Error", func(*result.Scope) int { panic(errBoomDo) }, errBoomDo},
+		{"RuntimeError", func(*result.Scope) int {
+			var m map[string]int
+			m["x"] = 1
+			return 0
+		}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				v := recover()
+				if v == nil {
+					t.Fatal("Expected the foreign panic to propagate")
+				}
+				if tt.want != nil && v != tt.want {
+					t.Errorf("Expected panic value %v, got %v", tt.want, v)
+				}
+			}()
+			result.Do(tt.fn)
+		})
+	}
+
+	t.Run("ScopeAfterDo", func(t *testing.T) {
+		var leaked *result.Scope
+		result.Do(func(s *result.Scope) int {
+			leaked = s
+			return 0
+		})
+		defer func() {
+			if v := recover(); v != "result: Scope used after its Do returned" {
+				t.Errorf("Expected a plain panic for a leaked Scope, got %v", v)
+			}
+		}()
+		result.Check(leaked, errBoomDo)
+	})
+}
+
+var sink result.Result[int, error]
+
+func BenchmarkDo(b *testing.B) {
+	b.Run("Ok", func(b *testing.B) {
+		for b.Loop() {
+			sink = result.Do(func(s *result.Scope) int {
+				n := result.Get(s, half(64))
+				n = result.Get(s, half(n))
+				return result.Get(s, half(n))
+			})
+		}
+	})
+	b.Run("Err", func(b *testing.B) {
+		for b.Loop() {
+			sink = result.Do(func(s *result.Scope) int {
+				n := result.Get(s, half(12))
+				n = result.Get(s, half(n))
+				return result.Get(s, half(n))
+			})
+		}
+	})
+}
+
+func BenchmarkFlatMap(b *testing.B) {
+	b.Run("Ok", func(b *testing.B) {
+		for b.Loop() {
+			sink = result.FlatMap(result.FlatMap(half(64), half), half)
+		}
+	})
+	b.Run("Err", func(b *testing.B) {
+		for b.Loop() {
+			sink = result.FlatMap(result.FlatMap(half(12), half), half)
+		}
+	})
+}
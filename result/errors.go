@@ -0,0 +1,40 @@
+package result
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrIs reports whether r is Err and its error matches target, as errors.Is does.
+func ErrIs[T any](r Result[T, error], target error) bool {
+	return r.IsErr() && errors.Is(*r.err, target)
+}
+
+// IsErrAs finds the first error in the chain of r that matches type E, as errors.As does.
+// It returns that error and true, or the zero value and false if r is Ok or nothing matches.
+func IsErrAs[E error, T any](r Result[T, error]) (E, bool) {
+	var target E
+	if r.IsOk() {
+		return target, false
+	}
+	ok := errors.As(*r.err, &target)
+	return target, ok
+}
+
+// WrapErr wraps the error of r with a message built from format and args, keeping the original
+// error in the chain for errors.Is and errors.As. The result reads "<message>: <error>". Ok is returned unchanged.
+func WrapErr[T any](r Result[T, error], format string, args ...any) Result[T, error] {
+	if r.IsOk() {
+		return r
+	}
+	return Err[T, error](fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), *r.err))
+}
+
+// Context wraps the error of r with msg, keeping the original error in the chain.
+// The result reads "<msg>: <error>". Ok is returned unchanged.
+func Context[T any](r Result[T, error], msg string) Result[T, error] {
+	if r.IsOk() {
+		return r
+	}
+	return Err[T, error](fmt.Errorf("%s: %w", msg, *r.err))
+}
@@ -0,0 +1,78 @@
+package result_test
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+
+	"github.com/gosuda/stdx/result"
+)
+
+var errNotFound = errors.New("not found")
+
+func TestResult_ErrIs(t *testing.T) {
+	tests := []struct {
+		name string
+		r    result.Result[int, error]
+		want bool
+	}{
+		{"Ok", result.Ok[int, error](1), false},
+		{"Match", result.Err[int, error](errNotFound), true},
+		{"Wrapped", result.Context(result.Err[int, error](errNotFound), "lookup"), true},
+		{"Other", result.Err[int, error](errors.New("not found")), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := result.ErrIs(tt.r, errNotFound); got != tt.want {
+				t.Errorf("Expected %t, got %t", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestResult_IsErrAs(t *testing.T) {
+	pathErr := &fs.PathError{Op: "open", Path: "/missing", Err: fs.ErrNotExist}
+	r := result.WrapErr(result.Err[int, error](pathErr), "loading %s", "config")
+
+	got, ok := result.IsErrAs[*fs.PathError](r)
+	if !ok || got != pathErr {
+		t.Errorf("Expected to find %v, got %v, %t", pathErr, got, ok)
+	}
+	if _, ok := result.IsErrAs[*fs.PathError](result.Err[int, error](errNotFound)); ok {
+		t.Error("Expected no match for a different error type")
+	}
+	if _, ok := result.IsErrAs[*fs.PathError](result.Ok[int, error](1)); ok {
+		t.Error("Expected no match for Ok")
+	}
+	if !result.ErrIs(r, fs.ErrNotExist) {
+		t.Error("Expected the wrapped chain to reach fs.ErrNotExist")
+	}
+}
+
+func TestResult_WrapErr(t *testing.T) {
+	r := result.WrapErr(result.Err[int, error](errNotFound), "user %d", 42)
+	if r.UnwrapErr().Error() != "user 42: not found" {
+		t.Errorf("Unexpected message %q", r.UnwrapErr())
+	}
+	if !errors.Is(r.UnwrapErr(), errNotFound) {
+		t.Error("Expected WrapErr to preserve the chain")
+	}
+
+	ok := result.WrapErr(result.Ok[int, error](1), "user %d", 42)
+	if !ok.IsOk() || ok.Unwrap() != 1 {
+		t.Error("Expected WrapErr to leave Ok unchanged")
+	}
+}
+
+func TestResult_Context(t *testing.T) {
+	r := result.Context(result.Context(result.Err[string, error](errNotFound), "read"), "load")
+	if r.UnwrapErr().Error() != "load: read: not found" {
+		t.Errorf("Unexpected message %q", r.UnwrapErr())
+	}
+	if !result.ErrIs(r, errNotFound) {
+		t.Error("Expected Context to preserve the chain")
+	}
+	if ok := result.Context(result.Ok[string, error]("v"), "load"); ok.Unwrap() != "v" {
+		t.Error("Expected Context to leave Ok unchanged")
+	}
+}
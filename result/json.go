@@ -0,0 +1,192 @@
+package result
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrInvalidJSON is returned when decoding JSON that does not match the expected Result format.
+var ErrInvalidJSON = errors.New("result: invalid Result JSON format")
+
+// ErrZeroResult is returned when encoding a zero Result, which is neither Ok nor Err.
+var ErrZeroResult = errors.New("result: cannot marshal a zero Result")
+
+// JSONFormat describes how a Result is encoded as a JSON object.
+//
+// With an empty TagKey the layout is externally tagged: {OkTag: value} or {ErrTag: error}.
+// Otherwise it is adjacently tagged: {TagKey: OkTag or ErrTag, ValueKey: value or error}.
+//
+// Decoding is strict: the object must hold exactly the keys of one variant.
+// When E is the error interface, errors are encoded as their Error() string, unless they
+// implement json.Marshaler, and decoded with errors.New.
+type JSONFormat struct {
+	TagKey   string
+	ValueKey string
+	OkTag    string
+	ErrTag   string
+}
+
+var (
+	// ExternallyTagged encodes {"ok": value} or {"err": error}. It is used by MarshalJSON and UnmarshalJSON.
+	ExternallyTagged = JSONFormat{OkTag: "ok", ErrTag: "err"}
+
+	// AdjacentlyTagged encodes {"type": "ok", "value": value} or {"type": "err", "value": error}.
+	AdjacentlyTagged = JSONFormat{TagKey: "type", ValueKey: "value", OkTag: "ok", ErrTag: "err"}
+)
+
+// MarshalJSONWith encodes r in format f.
+func MarshalJSONWith[T, E any](r Result[T, E], f JSONFormat) ([]byte, error) {
+	var tag string
+	var content []byte
+	var err error
+	switch {
+	case r.IsOk():
+		tag = f.OkTag
+		content, err = json.Marshal(*r.value)
+	case r.IsErr():
+		tag = f.ErrTag
+		content, err = marshalErr(*r.err)
+	default:
+		return nil, ErrZeroResult
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	if f.TagKey == "" {
+		writeMember(&buf, tag, content)
+	} else {
+		tagJSON, _ := json.Marshal(tag)
+		writeMember(&buf, f.TagKey, tagJSON)
+		buf.WriteByte(',')
+		writeMember(&buf, f.ValueKey, content)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSONWith decodes a Result encoded in format f.
+func UnmarshalJSONWith[T, E any](data []byte, f JSONFormat) (Result[T, E], error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return Result[T, E]{}, err
+	}
+	if raw == nil {
+		return Result[T, E]{}, fmt.Errorf("%w: expected an object, got null", ErrInvalidJSON)
+	}
+
+	var isOk bool
+	var content json.RawMessage
+	if f.TagKey == "" {
+		okData, hasOk := raw[f.OkTag]
+		errData, hasErr := raw[f.ErrTag]
+		switch {
+		case len(raw) != 1 || hasOk == hasErr:
+			return Result[T, E]{}, fmt.Errorf("%w: expected exactly one of %q and %q", ErrInvalidJSON, f.OkTag, f.ErrTag)
+		case hasOk:
+			isOk, content = true, okData
+		default:
+			content = errData
+		}
+	} else {
+		tagData, hasTag := raw[f.TagKey]
+		valueData, hasValue := raw[f.ValueKey]
+		if len(raw) != 2 || !hasTag || !hasValue {
+			return Result[T, E]{}, fmt.Errorf("%w: expected exactly the keys %q and %q", ErrInvalidJSON, f.TagKey, f.ValueKey)
+		}
+		var tag string
+		if err := json.Unmarshal(tagData, &tag); err != nil {
+			return Result[T, E]{}, fmt.Errorf("%w: %q must be a string", ErrInvalidJSON, f.TagKey)
+		}
+		switch tag {
+		case f.OkTag:
+			isOk = true
+		case f.ErrTag:
+		default:
+			return Result[T, E]{}, fmt.Errorf("%w: unknown %q %q", ErrInvalidJSON, f.TagKey, tag)
+		}
+		content = valueData
+	}
+
+	if isOk {
+		var value T
+		if err := json.Unmarshal(content, &value); err != nil {
+			return Result[T, E]{}, err
+		}
+		return Ok[T, E](value), nil
+	}
+	errValue, err := unmarshalErr[E](content)
+	if err != nil {
+		return Result[T, E]{}, err
+	}
+	return Err[T, E](errValue), nil
+}
+
+// Tagged is a Result that uses the AdjacentlyTagged format for JSON, for use in struct fields and collections.
+type Tagged[T, E any] struct {
+	Result[T, E]
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t Tagged[T, E]) MarshalJSON() ([]byte, error) {
+	return MarshalJSONWith(t.Result, AdjacentlyTagged)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *Tagged[T, E]) UnmarshalJSON(data []byte) error {
+	decoded, err := UnmarshalJSONWith[T, E](data, AdjacentlyTagged)
+	if err != nil {
+		return err
+	}
+	t.Result = decoded
+	return nil
+}
+
+// writeMember writes "key":value to buf.
+func writeMember(buf *bytes.Buffer, key string, value []byte) {
+	keyJSON, _ := json.Marshal(key)
+	buf.Write(keyJSON)
+	buf.WriteByte(':')
+	buf.Write(value)
+}
+
+// isErrorInterface reports whether E is the error interface itself.
+func isErrorInterface[E any]() bool {
+	return reflect.TypeFor[E]() == reflect.TypeFor[error]()
+}
+
+// marshalErr encodes e, using its Error() string when E is an interface type and the
+// dynamic value is an error without its own JSON encoding.
+func marshalErr[E any](e E) ([]byte, error) {
+	if reflect.TypeFor[E]().Kind() == reflect.Interface {
+		if _, ok := any(e).(json.Marshaler); !ok {
+			if err, ok := any(e).(error); ok {
+				return json.Marshal(err.Error())
+			}
+		}
+	}
+	return json.Marshal(e)
+}
+
+// unmarshalErr decodes an error value. When E is the error interface, a string decodes
+// with errors.New and null decodes to a nil error.
+func unmarshalErr[E any](data json.RawMessage) (E, error) {
+	var e E
+	if !isErrorInterface[E]() {
+		err := json.Unmarshal(data, &e)
+		return e, err
+	}
+	var message *string
+	if err := json.Unmarshal(data, &message); err != nil {
+		return e, fmt.Errorf("%w: error must be a string", ErrInvalidJSON)
+	}
+	if message != nil {
+		e = any(errors.New(*message)).(E)
+	}
+	return e, nil
+}
@@ -0,0 +1,169 @@
+package result_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/gosuda/stdx/result"
+)
+
+type codedError struct {
+	Code int `json:"code"`
+}
+
+func (e codedError) Error() string { return "code " + strconv.Itoa(e.Code) }
+
+type jsonError struct{}
+
+func (jsonError) Error() string                { return "json error" }
+func (jsonError) MarshalJSON() ([]byte, error) { return []byte(`{"custom":true}`), nil }
+
+func TestResult_JSONStrict(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+	}{
+		{"Both", `{"ok":1,"err":"e"}`},
+		{"Neither", `{}`},
+		{"Unknown", `{"value":1}`},
+		{"Extra", `{"ok":1,"extra":true}`},
+		{"Null", `null`},
+		{"Array", `[1]`},
+		{"WrongType", `{"ok":"one"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var r result.Result[int, string]
+			if err := json.Unmarshal([]byte(tt.json), &r); err == nil {
+				t.Errorf("Expected %s to be rejected, got %v", tt.json, r)
+			}
+		})
+	}
+
+	var r result.Result[int, string]
+	err := json.Unmarshal([]byte(`{"ok":1,"err":"e"}`), &r)
+	if !errors.Is(err, result.ErrInvalidJSON) {
+		t.Errorf("Expected ErrInvalidJSON, got %v", err)
+	}
+}
+
+func TestResult_JSONErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		r    any
+		json string
+	}{
+		{"ErrorString", result.Err[int, error](errors.New("disk full")), `{"err":"disk full"}`},
+		{"WrappedError", result.Context(result.Err[int, error](errors.New("disk full")), "save"), `{"err":"save: disk full"}`},
+		{"ErrorMarshaler", result.Err[int, error](jsonError{}), `{"err":{"custom":true}}`},
+		{"NilError", result.Err[int, error](nil), `{"err":null}`},
+		{"ConcreteError", result.Err[int, codedError](codedError{Code: 4}), `{"err":{"code":4}}`},
+		{"OkWithErrorType", result.Ok[int, error](7), `{"ok":7}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.r)
+			if err != nil || string(data) != tt.json {
+				t.Errorf("Expected %s, got %s (%v)", tt.json, data, err)
+			}
+		})
+	}
+
+	var r result.Result[int, error]
+	if err := json.Unmarshal([]byte(`{"err":"disk full"}`), &r); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+	if !r.IsErr() || r.UnwrapErr().Error() != "disk full" {
+		t.Errorf("Expected Err(disk full), got %v", r)
+	}
+	if err := json.Unmarshal([]byte(`{"err":{"code":1}}`), &r); !errors.Is(err, result.ErrInvalidJSON) {
+		t.Errorf("Expected a non-string error to be rejected, got %v", err)
+	}
+
+	var coded result.Result[int, codedError]
+	if err := json.Unmarshal([]byte(`{"err":{"code":4}}`), &coded); err != nil || coded.UnwrapErr().Code != 4 {
+		t.Errorf("Expected Err(code 4), got %v (%v)", coded, err)
+	}
+
+	if _, err := json.Marshal(result.Result[int, error]{}); !errors.Is(err, result.ErrZeroResult) {
+		t.Errorf("Expected ErrZeroResult for a zero Result, got %v", err)
+	}
+}
+
+func TestResult_JSONFormats(t *testing.T) {
+	custom := result.JSONFormat{TagKey: "status", ValueKey: "data", OkTag: "success", ErrTag: "failure"}
+	external := result.JSONFormat{OkTag: "value", ErrTag: "error"}
+
+	tests := []struct {
+		name   string
+		format result.JSONFormat
+		r      result.Result[[]int, error]
+		json   string
+	}{
+		{"ExternalOk", result.ExternallyTagged, result.Ok[[]int, error]([]int{1, 2}), `{"ok":[1,2]}`},
+		{"ExternalErr", result.ExternallyTagged, result.Err[[]int, error](errors.New("bad")), `{"err":"bad"}`},
+		{"AdjacentOk", result.AdjacentlyTagged, result.Ok[[]int, error]([]int{1}), `{"type":"ok","value":[1]}`},
+		{"AdjacentErr", result.AdjacentlyTagged, result.Err[[]int, error](errors.New("bad")), `{"type":"err","value":"bad"}`},
+		{"AdjacentNull", result.AdjacentlyTagged, result.Ok[[]int, error](nil), `{"type":"ok","value":null}`},
+		{"CustomOk", custom, result.Ok[[]int, error]([]int{3}), `{"status":"success","data":[3]}`},
+		{"CustomErr", custom, result.Err[[]int, error](errors.New("bad")), `{"status":"failure","data":"bad"}`},
+		{"RenamedExternal", external, result.Ok[[]int, error]([]int{}), `{"value":[]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := result.MarshalJSONWith(tt.r, tt.format)
+			if err != nil || string(data) != tt.json {
+				t.Fatalf("Expected %s, got %s (%v)", tt.json, data, err)
+			}
+			decoded, err := result.UnmarshalJSONWith[[]int, error](data, tt.format)
+			if err != nil {
+				t.Fatalf("Failed to unmarshal %s: %v", data, err)
+			}
+			if decoded.String() != tt.r.String() {
+				t.Errorf("Round trip mismatch: expected %v, got %v", tt.r, decoded)
+			}
+		})
+	}
+
+	rejected := []string{
+		`{"type":"ok"}`,
+		`{"value":1}`,
+		`{"type":"maybe","value":1}`,
+		`{"type":1,"value":1}`,
+		`{"type":"ok","value":[1],"extra":0}`,
+	}
+	for _, data := range rejected {
+		if _, err := result.UnmarshalJSONWith[[]int, error]([]byte(data), result.AdjacentlyTagged); !errors.Is(err, result.ErrInvalidJSON) {
+			t.Errorf("Expected %s to be rejected with ErrInvalidJSON, got %v", data, err)
+		}
+	}
+}
+
+func TestTagged_JSON(t *testing.T) {
+	type response struct {
+		Items []result.Tagged[int, error] `json:"items"`
+	}
+
+	in := response{Items: []result.Tagged[int, error]{
+		{Result: result.Ok[int, error](1)},
+		{Result: result.Err[int, error](errors.New("missing"))},
+	}}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	want := `{"items":[{"type":"ok","value":1},{"type":"err","value":"missing"}]}`
+	if string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+
+	var out response
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+	if len(out.Items) != 2 || out.Items[0].Unwrap() != 1 || out.Items[1].UnwrapErr().Error() != "missing" {
+		t.Errorf("Unexpected round trip %v", out.Items)
+	}
+}
@@ -0,0 +1,71 @@
+package result
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+)
+
+// ErrGoexit is the error of a Result produced by Go or Async when f calls runtime.Goexit.
+var ErrGoexit = errors.New("result: function called runtime.Goexit")
+
+// PanicError is the error of a Result produced by Catch when f panics.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value any
+
+	// Stack is the stack trace of the panicking goroutine, as formatted by debug.Stack.
+	Stack []byte
+}
+
+// Error implements the error interface.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error, so errors.Is and errors.As see through the panic.
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// Catch calls f and converts its (T, error) return into a Result like TryWith,
+// but also recovers a panic in f into Err(*PanicError).
+func Catch[T any](f func() (T, error)) (r Result[T, error]) {
+	defer func() {
+		if v := recover(); v != nil {
+			r = Err[T, error](&PanicError{Value: v, Stack: debug.Stack()})
+		}
+	}()
+	return TryWith(f)
+}
+
+// Go runs f in a new goroutine with Catch and delivers its Result on the returned channel,
+// which receives exactly one value and is then closed.
+func Go[T any](f func() (T, error)) <-chan Result[T, error] {
+	ch := make(chan Result[T, error], 1)
+	go func() {
+		sent := false
+		defer func() {
+			if !sent {
+				ch <- Err[T, error](ErrGoexit)
+			}
+			close(ch)
+		}()
+		ch <- Catch(f)
+		sent = true
+	}()
+	return ch
+}
+
+// Async runs f in a new goroutine with Catch and returns a function that waits for its Result.
+// The returned function may be called any number of times, from any goroutine, and always returns the same Result.
+func Async[T any](f func() (T, error)) func() Result[T, error] {
+	ch := Go(f)
+	return sync.OnceValue(func() Result[T, error] {
+		return <-ch
+	})
+}
@@ -0,0 +1,108 @@
+package result_test
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gosuda/stdx/result"
+)
+
+func TestCatch(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	tests := []struct {
+		name  string
+		f     func() (int, error)
+		ok    bool
+		panic any
+	}{
+		{"Ok", func() (int, error) { return 1, nil }, true, nil},
+		{"Err", func() (int, error) { return 0, errBoom }, false, nil},
+		{"PanicString", func() (int, error) { panic("bad") }, false, "bad"},
+		{"PanicError", func() (int, error) { panic(errBoom) }, false, errBoom},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := result.Catch(tt.f)
+			if r.IsOk() != tt.ok {
+				t.Fatalf("Expected IsOk %t, got %v", tt.ok, r)
+			}
+			if tt.panic == nil {
+				return
+			}
+			pe, ok := result.IsErrAs[*result.PanicError](r)
+			if !ok {
+				t.Fatalf("Expected a PanicError, got %v", r.UnwrapErr())
+			}
+			if pe.Value != tt.panic {
+				t.Errorf("Expected panic value %v, got %v", tt.panic, pe.Value)
+			}
+			if !strings.Contains(string(pe.Stack), "panic_test.go") {
+				t.Error("Expected the stack trace to include the panicking function")
+			}
+		})
+	}
+
+	r := result.Catch(func() (int, error) { panic(errBoom) })
+	if !result.ErrIs(r, errBoom) || r.UnwrapErr().Error() != "panic: boom" {
+		t.Errorf("Expected PanicError to unwrap to the panicked error, got %q", r.UnwrapErr())
+	}
+	r = result.Catch(func() (int, error) { panic(nil) })
+	if _, ok := result.IsErrAs[*result.PanicError](r); !ok {
+		t.Errorf("Expected panic(nil) to be caught, got %v", r)
+	}
+}
+
+func TestGo(t *testing.T) {
+	ch := result.Go(func() (string, error) { return "done", nil })
+	if r := <-ch; !r.IsOk() || r.Unwrap() != "done" {
+		t.Errorf("Expected Ok(done), got %v", r)
+	}
+	if _, open := <-ch; open {
+		t.Error("Expected the channel to be closed after the Result")
+	}
+
+	ch = result.Go(func() (string, error) { panic("bad") })
+	if r := <-ch; !r.IsErr() {
+		t.Errorf("Expected the panic to be delivered as Err, got %v", r)
+	}
+
+	ch = result.Go(func() (string, error) {
+		runtime.Goexit()
+		return "", nil
+	})
+	if r := <-ch; !result.ErrIs(r, result.ErrGoexit) {
+		t.Errorf("Expected ErrGoexit, got %v", r)
+	}
+}
+
+func TestAsync(t *testing.T) {
+	calls := 0
+	await := result.Async(func() (int, error) {
+		calls++
+		return 42, nil
+	})
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if r := await(); r.UnwrapOr(0) != 42 {
+				t.Errorf("Expected Ok(42), got %v", r)
+			}
+		}()
+	}
+	wg.Wait()
+	if await().Unwrap() != 42 || calls != 1 {
+		t.Errorf("Expected f to run once, ran %d times", calls)
+	}
+
+	failed := result.Async(func() (int, error) { panic("bad") })
+	if r := failed(); !r.IsErr() {
+		t.Errorf("Expected Err, got %v", r)
+	}
+}
@@ -4,7 +4,6 @@
 package result
 
 import (
-	"encoding/json"
 	"fmt"
 
 	"github.com/gosuda/stdx/option"
@@ -165,40 +164,19 @@ func (r Result[T, E]) String() string {
 	return fmt.Sprintf("Err(%v)", *r.err)
 }
 
-// MarshalJSON implements the json.Marshaler interface.
+// MarshalJSON implements the json.Marshaler interface using the ExternallyTagged format.
 func (r Result[T, E]) MarshalJSON() ([]byte, error) {
-	if r.IsOk() {
-		return json.Marshal(map[string]interface{}{"ok": *r.value})
-	}
-	return json.Marshal(map[string]interface{}{"err": *r.err})
+	return MarshalJSONWith(r, ExternallyTagged)
 }
 
-// UnmarshalJSON implements the json.Unmarshaler interface.
+// UnmarshalJSON implements the json.Unmarshaler interface using the ExternallyTagged format.
 func (r *Result[T, E]) UnmarshalJSON(data []byte) error {
-	var raw map[string]json.RawMessage
-	if err := json.Unmarshal(data, &raw); err != nil {
+	decoded, err := UnmarshalJSONWith[T, E](data, ExternallyTagged)
+	if err != nil {
 		return err
 	}
-
-	if okData, exists := raw["ok"]; exists {
-		var value T
-		if err := json.Unmarshal(okData, &value); err != nil {
-			return err
-		}
-		*r = Ok[T, E](value)
-		return nil
-	}
-
-	if errData, exists := raw["err"]; exists {
-		var errValue E
-		if err := json.Unmarshal(errData, &errValue); err != nil {
-			return err
-		}
-		*r = Err[T, E](errValue)
-		return nil
-	}
-
-	return fmt.Errorf("invalid Result JSON format")
+	*r = decoded
+	return nil
 }
 
 // Try converts a (value, error) pair into a Result.
@@ -214,3 +192,20 @@ func TryWith[T any](f func() (T, error)) Result[T, error] {
 	value, err := f()
 	return Try(value, err)
 }
+
+// OkOr converts an Option into a Result, mapping Some(v) to Ok(v) and None to Err(err).
+func OkOr[T, E any](o option.Option[T], err E) Result[T, E] {
+	if o.IsNone() {
+		return Err[T, E](err)
+	}
+	return Ok[T, E](o.Unwrap())
+}
+
+// OkOrElse converts an Option into a Result, mapping Some(v) to Ok(v) and None to Err(f()).
+// f is only called for None.
+func OkOrElse[T, E any](o option.Option[T], f func() E) Result[T, E] {
+	if o.IsNone() {
+		return Err[T, E](f())
+	}
+	return Ok[T, E](o.Unwrap())
+}
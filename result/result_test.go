@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/gosuda/stdx/option"
 	"github.com/gosuda/stdx/result"
 )
 
@@ -339,3 +340,26 @@ func TestResult_TryWith(t *testing.T) {
 		t.Error("Expected TryWith with error function to return Err")
 	}
 }
+
+func TestResult_OkOr(t *testing.T) {
+	ok := result.OkOr(option.Some(42), "missing")
+	if !ok.IsOk() || ok.Unwrap() != 42 {
+		t.Errorf("Expected Ok(42), got %v", ok)
+	}
+	err := result.OkOr(option.None[int](), "missing")
+	if !err.IsErr() || err.UnwrapErr() != "missing" {
+		t.Errorf("Expected Err(missing), got %v", err)
+	}
+
+	calls := 0
+	lazy := func() error {
+		calls++
+		return errors.New("missing")
+	}
+	if r := result.OkOrElse(option.Some(1), lazy); !r.IsOk() || calls != 0 {
+		t.Error("Expected OkOrElse on Some to be Ok without calling f")
+	}
+	if r := result.OkOrElse(option.None[int](), lazy); !r.IsErr() || calls != 1 {
+		t.Error("Expected OkOrElse on None to call f once")
+	}
+}
@@ -0,0 +1,128 @@
+package setx
+
+// The in-place functions below use a method of the same name on dst when it has one,
+// which lets implementations apply the whole operation atomically or more efficiently.
+type (
+	addAller[T comparable] interface {
+		AddAll(other Set[T]) bool
+	}
+	retainAller[T comparable] interface {
+		RetainAll(other Set[T]) bool
+	}
+	removeAller[T comparable] interface {
+		RemoveAll(other Set[T]) bool
+	}
+	symmetricDifferencer[T comparable] interface {
+		SymmetricDifference(other Set[T]) bool
+	}
+)
+
+// AddAll adds every element of other to dst and reports whether dst changed.
+func AddAll[T comparable](dst, other Set[T]) bool {
+	if s, ok := dst.(addAller[T]); ok {
+		return s.AddAll(other)
+	}
+	changed := false
+	for _, element := range other.ToSlice() {
+		if dst.Add(element) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// RetainAll removes every element of dst that is not in other and reports whether dst changed.
+func RetainAll[T comparable](dst, other Set[T]) bool {
+	if s, ok := dst.(retainAller[T]); ok {
+		return s.RetainAll(other)
+	}
+	changed := false
+	for _, element := range dst.ToSlice() {
+		if !other.Contains(element) && dst.Remove(element) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// RemoveAll removes every element of other from dst and reports whether dst changed.
+// It iterates whichever of the two sets is smaller.
+func RemoveAll[T comparable](dst, other Set[T]) bool {
+	if s, ok := dst.(removeAller[T]); ok {
+		return s.RemoveAll(other)
+	}
+	var candidates []T
+	if dst.Size() < other.Size() {
+		candidates = dst.ToSlice()
+	} else {
+		candidates = other.ToSlice()
+	}
+	changed := false
+	for _, element := range candidates {
+		if other.Contains(element) && dst.Remove(element) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// SymmetricDifference replaces dst with the elements that are in exactly one of dst and other
+// and reports whether dst changed.
+func SymmetricDifference[T comparable](dst, other Set[T]) bool {
+	if s, ok := dst.(symmetricDifferencer[T]); ok {
+		return s.SymmetricDifference(other)
+	}
+	elements := other.ToSlice()
+	for _, element := range elements {
+		if !dst.Remove(element) {
+			dst.Add(element)
+		}
+	}
+	return len(elements) > 0
+}
+
+// IsDisjoint reports whether a and b have no element in common. It iterates the smaller set.
+func IsDisjoint[T comparable](a, b Set[T]) bool {
+	if b.Size() < a.Size() {
+		a, b = b, a
+	}
+	return a.Find(b.Contains).IsNone()
+}
+
+// UnionOf adds the elements of all sets to dst and returns dst.
+// The result always has the implementation of dst, whatever the implementations of sets.
+func UnionOf[T comparable](dst Set[T], sets ...Set[T]) Set[T] {
+	for _, s := range sets {
+		AddAll(dst, s)
+	}
+	return dst
+}
+
+// IntersectionOf adds the elements common to all sets to dst and returns dst.
+// Only the smallest set is iterated; the others are probed with Contains.
+// The result always has the implementation of dst, whatever the implementations of sets.
+// If no sets are given dst is returned unchanged.
+func IntersectionOf[T comparable](dst Set[T], sets ...Set[T]) Set[T] {
+	if len(sets) == 0 {
+		return dst
+	}
+	smallest := 0
+	for i, s := range sets {
+		if s.Size() < sets[smallest].Size() {
+			smallest = i
+		}
+	}
+	for _, element := range sets[smallest].ToSlice() {
+		inAll := true
+		for i, s := range sets {
+			if i != smallest && !s.Contains(element) {
+				inAll = false
+				break
+			}
+		}
+		if inAll {
+			dst.Add(element)
+		}
+	}
+	return dst
+}
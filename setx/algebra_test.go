@@ -0,0 +1,135 @@
+package setx_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gosuda/stdx/option"
+	"github.com/gosuda/stdx/setx"
+	"github.com/gosuda/stdx/setx/concurrentset"
+	"github.com/gosuda/stdx/setx/hashset"
+	"github.com/gosuda/stdx/setx/treeset"
+)
+
+func hashSetOf(elements ...int) setx.Set[int] {
+	s := hashset.New[int]()
+	for _, e := range elements {
+		s.Add(e)
+	}
+	return s
+}
+
+func concurrentSetOf(elements ...int) setx.Set[int] {
+	s := concurrentset.New[int]()
+	for _, e := range elements {
+		s.Add(e)
+	}
+	return s
+}
+
+func sorted(s setx.Set[int]) []int {
+	elements := s.ToSlice()
+	slices.Sort(elements)
+	return elements
+}
+
+func TestInPlace(t *testing.T) {
+	for name, of := range map[string]func(...int) setx.Set[int]{
+		"HashSet":       hashSetOf,
+		"ConcurrentSet": concurrentSetOf,
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := of(1, 2, 3)
+			if !setx.AddAll(s, hashSetOf(3, 4)) || setx.AddAll(s, hashSetOf(1, 4)) {
+				t.Error("AddAll should report whether the set changed")
+			}
+			if got := sorted(s); !slices.Equal(got, []int{1, 2, 3, 4}) {
+				t.Errorf("AddAll: unexpected %v", got)
+			}
+
+			if !setx.RetainAll(s, hashSetOf(2, 3, 4, 9)) || setx.RetainAll(s, hashSetOf(2, 3, 4)) {
+				t.Error("RetainAll should report whether the set changed")
+			}
+			if got := sorted(s); !slices.Equal(got, []int{2, 3, 4}) {
+				t.Errorf("RetainAll: unexpected %v", got)
+			}
+
+			if !setx.RemoveAll(s, hashSetOf(4, 5, 6, 7, 8)) || setx.RemoveAll(s, hashSetOf(9)) {
+				t.Error("RemoveAll should report whether the set changed")
+			}
+			if got := sorted(s); !slices.Equal(got, []int{2, 3}) {
+				t.Errorf("RemoveAll: unexpected %v", got)
+			}
+
+			if !setx.SymmetricDifference(s, concurrentSetOf(3, 5)) || setx.SymmetricDifference(s, hashSetOf()) {
+				t.Error("SymmetricDifference should report whether the set changed")
+			}
+			if got := sorted(s); !slices.Equal(got, []int{2, 5}) {
+				t.Errorf("SymmetricDifference: unexpected %v", got)
+			}
+		})
+	}
+}
+
+func TestIsDisjoint(t *testing.T) {
+	a := hashSetOf(1, 2, 3)
+	if setx.IsDisjoint(a, concurrentSetOf(3, 4)) {
+		t.Error("Sets sharing 3 should not be disjoint")
+	}
+	if !setx.IsDisjoint(a, concurrentSetOf(4, 5, 6, 7)) || !setx.IsDisjoint(a, hashSetOf()) {
+		t.Error("Sets without common elements should be disjoint")
+	}
+}
+
+// countingSet counts calls to ForEach, ToSlice and Find to verify which operand is iterated.
+type countingSet struct {
+	setx.Set[int]
+	iterations int
+}
+
+func (c *countingSet) ForEach(fn func(int)) {
+	c.iterations++
+	c.Set.ForEach(fn)
+}
+
+func (c *countingSet) ToSlice() []int {
+	c.iterations++
+	return c.Set.ToSlice()
+}
+
+func (c *countingSet) Find(predicate func(int) bool) option.Option[int] {
+	c.iterations++
+	return c.Set.Find(predicate)
+}
+
+func TestUnionOf(t *testing.T) {
+	dst := treeset.New[int]()
+	result := setx.UnionOf[int](dst, hashSetOf(5, 1), concurrentSetOf(3, 1), hashSetOf(9))
+
+	if result != setx.Set[int](dst) {
+		t.Error("UnionOf should return dst")
+	}
+	if got := result.ToSlice(); !slices.Equal(got, []int{1, 3, 5, 9}) {
+		t.Errorf("Unexpected union %v", got)
+	}
+}
+
+func TestIntersectionOf(t *testing.T) {
+	large := &countingSet{Set: hashSetOf(1, 2, 3, 4, 5, 6, 7, 8)}
+	small := &countingSet{Set: concurrentSetOf(2, 4, 10)}
+	medium := &countingSet{Set: hashSetOf(2, 3, 4, 5, 10)}
+
+	result := setx.IntersectionOf[int](treeset.New[int](), large, small, medium)
+	if got := result.ToSlice(); !slices.Equal(got, []int{2, 4}) {
+		t.Errorf("Unexpected intersection %v", got)
+	}
+	if large.iterations != 0 || medium.iterations != 0 || small.iterations != 1 {
+		t.Errorf("Only the smallest set should be iterated, got %d/%d/%d",
+			large.iterations, small.iterations, medium.iterations)
+	}
+
+	empty := setx.IntersectionOf[int](hashset.New[int]())
+	if !empty.IsEmpty() {
+		t.Error("IntersectionOf without sets should leave dst empty")
+	}
+}
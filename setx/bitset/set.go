@@ -0,0 +1,383 @@
+// Package bitset provides a compact set of small non-negative integers backed by a bit vector.
+package bitset
+
+import (
+	"encoding/binary"
+	"errors"
+	"iter"
+	"math/bits"
+
+	"github.com/gosuda/stdx/option"
+	"github.com/gosuda/stdx/result"
+	"github.com/gosuda/stdx/setx"
+)
+
+var _ setx.Set[uint] = (*BitSet)(nil)
+
+// ErrInvalidEncoding is returned by UnmarshalBinary when the data is not a multiple of 8 bytes.
+var ErrInvalidEncoding = errors.New("bitset: invalid binary encoding")
+
+const wordSize = 64
+
+// BitSet is a set of uint stored as one bit per possible element.
+// Memory use is proportional to the largest element, so it is best suited for dense sets of small integers.
+//
+// Set operations with another *BitSet work a word at a time; other setx.Set implementations
+// are handled element by element.
+type BitSet struct {
+	words []uint64
+}
+
+func New() *BitSet {
+	return &BitSet{}
+}
+
+// NewWithCapacity creates a BitSet that can hold elements up to n-1 without growing.
+func NewWithCapacity(n uint) *BitSet {
+	return &BitSet{words: make([]uint64, (n+wordSize-1)/wordSize)}
+}
+
+// Of creates a BitSet containing the given elements.
+func Of(elements ...uint) *BitSet {
+	b := New()
+	for _, element := range elements {
+		b.Add(element)
+	}
+	return b
+}
+
+func index(element uint) (word uint, mask uint64) {
+	return element / wordSize, 1 << (element % wordSize)
+}
+
+// grow makes sure word i exists.
+func (b *BitSet) grow(i uint) {
+	if i < uint(len(b.words)) {
+		return
+	}
+	if i < uint(cap(b.words)) {
+		b.words = b.words[:i+1]
+		return
+	}
+	words := make([]uint64, i+1, max(i+1, uint(2*cap(b.words))))
+	copy(words, b.words)
+	b.words = words
+}
+
+// trim drops trailing zero words so that the encoding and Equal do not depend on history.
+func (b *BitSet) trim() {
+	n := len(b.words)
+	for n > 0 && b.words[n-1] == 0 {
+		n--
+	}
+	b.words = b.words[:n]
+}
+
+// Add implements setx.Set.
+func (b *BitSet) Add(element uint) bool {
+	i, mask := index(element)
+	b.grow(i)
+	if b.words[i]&mask != 0 {
+		return false
+	}
+	b.words[i] |= mask
+	return true
+}
+
+// Clear implements setx.Set. The allocated capacity is kept.
+func (b *BitSet) Clear() {
+	clear(b.words)
+	b.words = b.words[:0]
+}
+
+// Contains implements setx.Set.
+func (b *BitSet) Contains(element uint) bool {
+	i, mask := index(element)
+	return i < uint(len(b.words)) && b.words[i]&mask != 0
+}
+
+// Difference implements setx.Set. The result is a new *BitSet.
+func (b *BitSet) Difference(other setx.Set[uint]) setx.Set[uint] {
+	if o, ok := other.(*BitSet); ok {
+		result := b.Clone()
+		result.DifferenceWith(o)
+		return result
+	}
+	return b.Filter(func(element uint) bool {
+		return !other.Contains(element)
+	})
+}
+
+// Intersection implements setx.Set. The result is a new *BitSet.
+func (b *BitSet) Intersection(other setx.Set[uint]) setx.Set[uint] {
+	if o, ok := other.(*BitSet); ok {
+		result := b.Clone()
+		result.IntersectWith(o)
+		return result
+	}
+	return b.Filter(other.Contains)
+}
+
+// IsEmpty implements setx.Set.
+func (b *BitSet) IsEmpty() bool {
+	for _, w := range b.words {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSubsetOf implements setx.Set.
+func (b *BitSet) IsSubsetOf(other setx.Set[uint]) bool {
+	if o, ok := other.(*BitSet); ok {
+		for i, w := range b.words {
+			if w&^o.word(i) != 0 {
+				return false
+			}
+		}
+		return true
+	}
+	for element := range b.All() {
+		if !other.Contains(element) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSupersetOf implements setx.Set.
+func (b *BitSet) IsSupersetOf(other setx.Set[uint]) bool {
+	return other.IsSubsetOf(b)
+}
+
+// Remove implements setx.Set.
+func (b *BitSet) Remove(element uint) bool {
+	i, mask := index(element)
+	if i >= uint(len(b.words)) || b.words[i]&mask == 0 {
+		return false
+	}
+	b.words[i] &^= mask
+	return true
+}
+
+// Size implements setx.Set. It is the same as PopCount.
+func (b *BitSet) Size() int {
+	return b.PopCount()
+}
+
+// ToSlice implements setx.Set. Elements are returned in ascending order.
+func (b *BitSet) ToSlice() []uint {
+	result := make([]uint, 0, b.PopCount())
+	for element := range b.All() {
+		result = append(result, element)
+	}
+	return result
+}
+
+// ForEach implements setx.Set. Elements are visited in ascending order.
+func (b *BitSet) ForEach(fn func(element uint)) {
+	for element := range b.All() {
+		fn(element)
+	}
+}
+
+// Union implements setx.Set. The result is a new *BitSet.
+func (b *BitSet) Union(other setx.Set[uint]) setx.Set[uint] {
+	result := b.Clone()
+	if o, ok := other.(*BitSet); ok {
+		result.UnionWith(o)
+		return result
+	}
+	other.ForEach(func(element uint) {
+		result.Add(element)
+	})
+	return result
+}
+
+// Find implements setx.Set. Returns the smallest matching element.
+func (b *BitSet) Find(predicate func(uint) bool) option.Option[uint] {
+	for element := range b.All() {
+		if predicate(element) {
+			return option.Some(element)
+		}
+	}
+	return option.None[uint]()
+}
+
+// GetAny implements setx.Set. Returns the smallest element.
+func (b *BitSet) GetAny() option.Option[uint] {
+	return b.NextSet(0)
+}
+
+// TryRemove implements setx.Set.
+func (b *BitSet) TryRemove(element uint) result.Result[uint, error] {
+	if b.Remove(element) {
+		return result.Ok[uint, error](element)
+	}
+	return result.Err[uint, error](setx.ErrElementNotFound)
+}
+
+// Filter implements setx.Set. The result is a new *BitSet.
+func (b *BitSet) Filter(predicate func(uint) bool) setx.Set[uint] {
+	result := NewWithCapacity(uint(len(b.words)) * wordSize)
+	for element := range b.All() {
+		if predicate(element) {
+			i, mask := index(element)
+			result.words[i] |= mask
+		}
+	}
+	result.trim()
+	return result
+}
+
+// word returns word i, or 0 if it is beyond the end.
+func (b *BitSet) word(i int) uint64 {
+	if i < len(b.words) {
+		return b.words[i]
+	}
+	return 0
+}
+
+// PopCount returns the number of elements in the set.
+func (b *BitSet) PopCount() int {
+	count := 0
+	for _, w := range b.words {
+		count += bits.OnesCount64(w)
+	}
+	return count
+}
+
+// NextSet returns the smallest element greater than or equal to from, or None if there is none.
+func (b *BitSet) NextSet(from uint) option.Option[uint] {
+	i, _ := index(from)
+	if i >= uint(len(b.words)) {
+		return option.None[uint]()
+	}
+	w := b.words[i] >> (from % wordSize)
+	if w != 0 {
+		return option.Some(from + uint(bits.TrailingZeros64(w)))
+	}
+	for i++; i < uint(len(b.words)); i++ {
+		if b.words[i] != 0 {
+			return option.Some(i*wordSize + uint(bits.TrailingZeros64(b.words[i])))
+		}
+	}
+	return option.None[uint]()
+}
+
+// NextClear returns the smallest integer greater than or equal to from that is not in the set.
+func (b *BitSet) NextClear(from uint) uint {
+	i, _ := index(from)
+	if i >= uint(len(b.words)) {
+		return from
+	}
+	w := ^b.words[i] >> (from % wordSize)
+	if w != 0 {
+		return from + uint(bits.TrailingZeros64(w))
+	}
+	for i++; i < uint(len(b.words)); i++ {
+		if b.words[i] != ^uint64(0) {
+			return i*wordSize + uint(bits.TrailingZeros64(^b.words[i]))
+		}
+	}
+	return uint(len(b.words)) * wordSize
+}
+
+// All returns an iterator over the elements in ascending order.
+// The set must not be modified during iteration.
+func (b *BitSet) All() iter.Seq[uint] {
+	return func(yield func(uint) bool) {
+		for i := 0; i < len(b.words); i++ {
+			for w := b.words[i]; w != 0; w &= w - 1 {
+				if !yield(uint(i)*wordSize + uint(bits.TrailingZeros64(w))) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Clone returns an independent copy of the set.
+func (b *BitSet) Clone() *BitSet {
+	words := make([]uint64, len(b.words))
+	copy(words, b.words)
+	result := &BitSet{words: words}
+	result.trim()
+	return result
+}
+
+// Equal reports whether b and other contain the same elements.
+func (b *BitSet) Equal(other *BitSet) bool {
+	for i := range max(len(b.words), len(other.words)) {
+		if b.word(i) != other.word(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// UnionWith adds every element of other to b.
+func (b *BitSet) UnionWith(other *BitSet) {
+	if len(other.words) > len(b.words) {
+		b.grow(uint(len(other.words) - 1))
+	}
+	for i, w := range other.words {
+		b.words[i] |= w
+	}
+}
+
+// IntersectWith removes every element of b that is not in other.
+func (b *BitSet) IntersectWith(other *BitSet) {
+	for i := range b.words {
+		b.words[i] &= other.word(i)
+	}
+	b.trim()
+}
+
+// DifferenceWith removes every element of other from b.
+func (b *BitSet) DifferenceWith(other *BitSet) {
+	for i := range min(len(b.words), len(other.words)) {
+		b.words[i] &^= other.words[i]
+	}
+	b.trim()
+}
+
+// SymmetricDifferenceWith leaves in b the elements that are in exactly one of b and other.
+func (b *BitSet) SymmetricDifferenceWith(other *BitSet) {
+	if len(other.words) > len(b.words) {
+		b.grow(uint(len(other.words) - 1))
+	}
+	for i, w := range other.words {
+		b.words[i] ^= w
+	}
+	b.trim()
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding is the sequence of 64-bit words in little-endian order, without trailing zero words.
+func (b *BitSet) MarshalBinary() ([]byte, error) {
+	n := len(b.words)
+	for n > 0 && b.words[n-1] == 0 {
+		n--
+	}
+	data := make([]byte, 0, n*8)
+	for _, w := range b.words[:n] {
+		data = binary.LittleEndian.AppendUint64(data, w)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. The current contents are replaced.
+func (b *BitSet) UnmarshalBinary(data []byte) error {
+	if len(data)%8 != 0 {
+		return ErrInvalidEncoding
+	}
+	words := make([]uint64, len(data)/8)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+	b.words = words
+	b.trim()
+	return nil
+}
@@ -0,0 +1,623 @@
+package bitset_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/gosuda/stdx/setx"
+	"github.com/gosuda/stdx/setx/bitset"
+	"github.com/gosuda/stdx/setx/hashset"
+)
+
+// createBitSet is a factory function for creating BitSet instances
+func createBitSet() setx.Set[uint] {
+	return bitset.New()
+}
+
+func TestBitSet_Add(t *testing.T) {
+	testSetAdd(t, createBitSet)
+}
+
+func TestBitSet_Remove(t *testing.T) {
+	testSetRemove(t, createBitSet)
+}
+
+func TestBitSet_Contains(t *testing.T) {
+	testSetContains(t, createBitSet)
+}
+
+func TestBitSet_Size(t *testing.T) {
+	testSetSize(t, createBitSet)
+}
+
+func TestBitSet_IsEmpty(t *testing.T) {
+	testSetIsEmpty(t, createBitSet)
+}
+
+func TestBitSet_Clear(t *testing.T) {
+	testSetClear(t, createBitSet)
+}
+
+func TestBitSet_ToSlice(t *testing.T) {
+	testSetToSlice(t, createBitSet)
+}
+
+func TestBitSet_ForEach(t *testing.T) {
+	testSetForEach(t, createBitSet)
+}
+
+func TestBitSet_Union(t *testing.T) {
+	testSetUnion(t, createBitSet)
+}
+
+func TestBitSet_Intersection(t *testing.T) {
+	testSetIntersection(t, createBitSet)
+}
+
+func TestBitSet_Difference(t *testing.T) {
+	testSetDifference(t, createBitSet)
+}
+
+func TestBitSet_IsSubsetOf(t *testing.T) {
+	testSetIsSubsetOf(t, createBitSet)
+}
+
+func TestBitSet_IsSupersetOf(t *testing.T) {
+	testSetIsSupersetOf(t, createBitSet)
+}
+
+func TestBitSet_Find(t *testing.T) {
+	testSetFind(t, createBitSet)
+}
+
+func TestBitSet_GetAny(t *testing.T) {
+	testSetGetAny(t, createBitSet)
+}
+
+func TestBitSet_TryRemove(t *testing.T) {
+	testSetTryRemove(t, createBitSet)
+}
+
+func TestBitSet_Filter(t *testing.T) {
+	testSetFilter(t, createBitSet)
+}
+
+func TestBitSet_Ordering(t *testing.T) {
+	set := bitset.Of(130, 3, 64, 0, 63, 1000)
+
+	expected := []uint{0, 3, 63, 64, 130, 1000}
+	if got := set.ToSlice(); !slices.Equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	if set.PopCount() != len(expected) {
+		t.Errorf("Expected pop count %d, got %d", len(expected), set.PopCount())
+	}
+	if set.GetAny().Unwrap() != 0 {
+		t.Error("GetAny should return the smallest element")
+	}
+}
+
+func TestBitSet_NextSetNextClear(t *testing.T) {
+	set := bitset.Of(1, 2, 3, 70, 200)
+
+	cases := []struct {
+		from uint
+		next int
+	}{
+		{0, 1}, {3, 3}, {4, 70}, {70, 70}, {71, 200}, {201, -1}, {5000, -1},
+	}
+	for _, c := range cases {
+		got := set.NextSet(c.from)
+		if c.next < 0 {
+			if got.IsSome() {
+				t.Errorf("NextSet(%d): expected None, got %v", c.from, got)
+			}
+		} else if got.UnwrapOr(0) != uint(c.next) {
+			t.Errorf("NextSet(%d): expected %d, got %v", c.from, c.next, got)
+		}
+	}
+
+	if got := set.NextClear(1); got != 4 {
+		t.Errorf("NextClear(1): expected 4, got %d", got)
+	}
+	if got := set.NextClear(5000); got != 5000 {
+		t.Errorf("NextClear(5000): expected 5000, got %d", got)
+	}
+
+	full := bitset.New()
+	for i := uint(0); i < 128; i++ {
+		full.Add(i)
+	}
+	if got := full.NextClear(10); got != 128 {
+		t.Errorf("NextClear on full words: expected 128, got %d", got)
+	}
+}
+
+func TestBitSet_InPlace(t *testing.T) {
+	a := bitset.Of(1, 2, 3, 100)
+	b := bitset.Of(3, 4, 100, 500)
+
+	union := a.Clone()
+	union.UnionWith(b)
+	if got := union.ToSlice(); !slices.Equal(got, []uint{1, 2, 3, 4, 100, 500}) {
+		t.Errorf("Unexpected union %v", got)
+	}
+
+	intersection := a.Clone()
+	intersection.IntersectWith(b)
+	if got := intersection.ToSlice(); !slices.Equal(got, []uint{3, 100}) {
+		t.Errorf("Unexpected intersection %v", got)
+	}
+
+	difference := a.Clone()
+	difference.DifferenceWith(b)
+	if got := difference.ToSlice(); !slices.Equal(got, []uint{1, 2}) {
+		t.Errorf("Unexpected difference %v", got)
+	}
+
+	symmetric := a.Clone()
+	symmetric.SymmetricDifferenceWith(b)
+	if got := symmetric.ToSlice(); !slices.Equal(got, []uint{1, 2, 4, 500}) {
+		t.Errorf("Unexpected symmetric difference %v", got)
+	}
+
+	if !a.Equal(bitset.Of(1, 2, 3, 100)) {
+		t.Error("In-place operations on clones should not modify the original")
+	}
+}
+
+func TestBitSet_MixedBackends(t *testing.T) {
+	a := bitset.Of(1, 2, 3)
+	other := hashset.New[uint]()
+	other.Add(2)
+	other.Add(3)
+	other.Add(4)
+
+	if got := a.Union(other).ToSlice(); !slices.Equal(got, []uint{1, 2, 3, 4}) {
+		t.Errorf("Unexpected union %v", got)
+	}
+	if got := a.Intersection(other).ToSlice(); !slices.Equal(got, []uint{2, 3}) {
+		t.Errorf("Unexpected intersection %v", got)
+	}
+	if got := a.Difference(other).ToSlice(); !slices.Equal(got, []uint{1}) {
+		t.Errorf("Unexpected difference %v", got)
+	}
+	if !bitset.Of(2, 3).IsSubsetOf(other) || a.IsSubsetOf(other) {
+		t.Error("Unexpected subset relation")
+	}
+}
+
+func TestBitSet_Equal(t *testing.T) {
+	a := bitset.Of(1, 500)
+	a.Remove(500)
+	if !a.Equal(bitset.Of(1)) || a.Equal(bitset.Of(2)) {
+		t.Error("Equal should ignore trailing empty words")
+	}
+	if !bitset.New().Equal(bitset.NewWithCapacity(1024)) {
+		t.Error("Empty sets should be equal regardless of capacity")
+	}
+}
+
+func TestBitSet_Binary(t *testing.T) {
+	set := bitset.Of(0, 7, 64, 999)
+	set.Add(5000)
+	set.Remove(5000)
+
+	data, err := set.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+	if len(data) != 16*8 {
+		t.Errorf("Expected trailing zero words to be trimmed, got %d bytes", len(data))
+	}
+
+	decoded := bitset.New()
+	decoded.Add(3)
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if !decoded.Equal(set) {
+		t.Errorf("Expected %v, got %v", set.ToSlice(), decoded.ToSlice())
+	}
+
+	if err := decoded.UnmarshalBinary([]byte{1, 2, 3}); !errors.Is(err, bitset.ErrInvalidEncoding) {
+		t.Errorf("Expected ErrInvalidEncoding, got %v", err)
+	}
+}
+
+// Common test functions that can be reused for any Set implementation
+
+func testSetAdd(t *testing.T, factory func() setx.Set[uint]) {
+	set := factory()
+
+	// Test adding new element
+	if !set.Add(1) {
+		t.Error("Add(1) should return true for new element")
+	}
+	if !set.Contains(1) {
+		t.Error("Set should contain 1 after adding")
+	}
+
+	// Test adding duplicate element
+	if set.Add(1) {
+		t.Error("Add(1) should return false for duplicate element")
+	}
+
+	// Test size after additions
+	set.Add(2)
+	set.Add(3)
+	if set.Size() != 3 {
+		t.Errorf("Expected size 3, got %d", set.Size())
+	}
+}
+
+func testSetRemove(t *testing.T, factory func() setx.Set[uint]) {
+	set := factory()
+	set.Add(1)
+	set.Add(2)
+	set.Add(3)
+
+	// Test removing existing element
+	if !set.Remove(2) {
+		t.Error("Remove(2) should return true for existing element")
+	}
+	if set.Contains(2) {
+		t.Error("Set should not contain 2 after removal")
+	}
+
+	// Test removing non-existing element
+	if set.Remove(4) {
+		t.Error("Remove(4) should return false for non-existing element")
+	}
+
+	// Test size after removal
+	if set.Size() != 2 {
+		t.Errorf("Expected size 2, got %d", set.Size())
+	}
+}
+
+func testSetContains(t *testing.T, factory func() setx.Set[uint]) {
+	set := factory()
+	set.Add(1)
+	set.Add(2)
+
+	if !set.Contains(1) {
+		t.Error("Set should contain 1")
+	}
+	if !set.Contains(2) {
+		t.Error("Set should contain 2")
+	}
+	if set.Contains(3) {
+		t.Error("Set should not contain 3")
+	}
+}
+
+func testSetSize(t *testing.T, factory func() setx.Set[uint]) {
+	set := factory()
+
+	if set.Size() != 0 {
+		t.Errorf("Empty set size should be 0, got %d", set.Size())
+	}
+
+	set.Add(1)
+	if set.Size() != 1 {
+		t.Errorf("Size should be 1, got %d", set.Size())
+	}
+
+	set.Add(2)
+	set.Add(3)
+	if set.Size() != 3 {
+		t.Errorf("Size should be 3, got %d", set.Size())
+	}
+
+	set.Remove(2)
+	if set.Size() != 2 {
+		t.Errorf("Size should be 2 after removal, got %d", set.Size())
+	}
+}
+
+func testSetIsEmpty(t *testing.T, factory func() setx.Set[uint]) {
+	set := factory()
+
+	if !set.IsEmpty() {
+		t.Error("New set should be empty")
+	}
+
+	set.Add(1)
+	if set.IsEmpty() {
+		t.Error("Set with elements should not be empty")
+	}
+
+	set.Remove(1)
+	if !set.IsEmpty() {
+		t.Error("Set should be empty after removing all elements")
+	}
+}
+
+func testSetClear(t *testing.T, factory func() setx.Set[uint]) {
+	set := factory()
+	set.Add(1)
+	set.Add(2)
+	set.Add(3)
+
+	set.Clear()
+
+	if !set.IsEmpty() {
+		t.Error("Set should be empty after Clear()")
+	}
+	if set.Size() != 0 {
+		t.Errorf("Size should be 0 after Clear(), got %d", set.Size())
+	}
+	if set.Contains(1) || set.Contains(2) || set.Contains(3) {
+		t.Error("Set should not contain any elements after Clear()")
+	}
+}
+
+func testSetToSlice(t *testing.T, factory func() setx.Set[uint]) {
+	set := factory()
+	set.Add(1)
+	set.Add(2)
+	set.Add(3)
+
+	slice := set.ToSlice()
+
+	if len(slice) != 3 {
+		t.Errorf("Expected slice length 3, got %d", len(slice))
+	}
+
+	// Check all elements are present (order doesn't matter)
+	found := make(map[uint]bool)
+	for _, v := range slice {
+		found[v] = true
+	}
+
+	if !found[1] || !found[2] || !found[3] {
+		t.Error("ToSlice() should contain all set elements")
+	}
+}
+
+func testSetForEach(t *testing.T, factory func() setx.Set[uint]) {
+	set := factory()
+	set.Add(1)
+	set.Add(2)
+	set.Add(3)
+
+	visited := make(map[uint]bool)
+	set.ForEach(func(element uint) {
+		visited[element] = true
+	})
+
+	if len(visited) != 3 {
+		t.Errorf("Expected to visit 3 elements, visited %d", len(visited))
+	}
+
+	if !visited[1] || !visited[2] || !visited[3] {
+		t.Error("ForEach should visit all elements")
+	}
+}
+
+func testSetUnion(t *testing.T, factory func() setx.Set[uint]) {
+	set1 := factory()
+	set2 := factory()
+
+	set1.Add(1)
+	set1.Add(2)
+	set2.Add(2)
+	set2.Add(3)
+
+	union := set1.Union(set2)
+
+	if union.Size() != 3 {
+		t.Errorf("Union size should be 3, got %d", union.Size())
+	}
+
+	if !union.Contains(1) || !union.Contains(2) || !union.Contains(3) {
+		t.Error("Union should contain elements from both sets")
+	}
+}
+
+func testSetIntersection(t *testing.T, factory func() setx.Set[uint]) {
+	set1 := factory()
+	set2 := factory()
+
+	set1.Add(1)
+	set1.Add(2)
+	set1.Add(3)
+	set2.Add(2)
+	set2.Add(3)
+	set2.Add(4)
+
+	intersection := set1.Intersection(set2)
+
+	if intersection.Size() != 2 {
+		t.Errorf("Intersection size should be 2, got %d", intersection.Size())
+	}
+
+	if !intersection.Contains(2) || !intersection.Contains(3) {
+		t.Error("Intersection should contain common elements")
+	}
+
+	if intersection.Contains(1) || intersection.Contains(4) {
+		t.Error("Intersection should not contain non-common elements")
+	}
+}
+
+func testSetDifference(t *testing.T, factory func() setx.Set[uint]) {
+	set1 := factory()
+	set2 := factory()
+
+	set1.Add(1)
+	set1.Add(2)
+	set1.Add(3)
+	set2.Add(2)
+	set2.Add(4)
+
+	difference := set1.Difference(set2)
+
+	if difference.Size() != 2 {
+		t.Errorf("Difference size should be 2, got %d", difference.Size())
+	}
+
+	if !difference.Contains(1) || !difference.Contains(3) {
+		t.Error("Difference should contain elements only in first set")
+	}
+
+	if difference.Contains(2) || difference.Contains(4) {
+		t.Error("Difference should not contain common or second set only elements")
+	}
+}
+
+func testSetIsSubsetOf(t *testing.T, factory func() setx.Set[uint]) {
+	set1 := factory()
+	set2 := factory()
+
+	set1.Add(1)
+	set1.Add(2)
+	set2.Add(1)
+	set2.Add(2)
+	set2.Add(3)
+
+	if !set1.IsSubsetOf(set2) {
+		t.Error("set1 should be subset of set2")
+	}
+
+	if set2.IsSubsetOf(set1) {
+		t.Error("set2 should not be subset of set1")
+	}
+
+	// Test with empty set
+	emptySet := factory()
+	if !emptySet.IsSubsetOf(set1) {
+		t.Error("Empty set should be subset of any set")
+	}
+}
+
+func testSetIsSupersetOf(t *testing.T, factory func() setx.Set[uint]) {
+	set1 := factory()
+	set2 := factory()
+
+	set1.Add(1)
+	set1.Add(2)
+	set1.Add(3)
+	set2.Add(1)
+	set2.Add(2)
+
+	if !set1.IsSupersetOf(set2) {
+		t.Error("set1 should be superset of set2")
+	}
+
+	if set2.IsSupersetOf(set1) {
+		t.Error("set2 should not be superset of set1")
+	}
+
+	// Test with empty set
+	emptySet := factory()
+	if !set1.IsSupersetOf(emptySet) {
+		t.Error("Any set should be superset of empty set")
+	}
+}
+
+// Test functions for new Option/Result-based methods
+
+func testSetFind(t *testing.T, factory func() setx.Set[uint]) {
+	set := factory()
+	set.Add(1)
+	set.Add(2)
+	set.Add(3)
+	set.Add(4)
+	set.Add(5)
+
+	// Find even number
+	result := set.Find(func(x uint) bool { return x%2 == 0 })
+	if result.IsNone() {
+		t.Error("Should find an even number")
+	}
+
+	value := result.Unwrap()
+	if value%2 != 0 {
+		t.Errorf("Found value should be even, got %d", value)
+	}
+
+	// Find number greater than 10 (should not exist)
+	result = set.Find(func(x uint) bool { return x > 10 })
+	if result.IsSome() {
+		t.Error("Should not find number greater than 10")
+	}
+}
+
+func testSetGetAny(t *testing.T, factory func() setx.Set[uint]) {
+	set := factory()
+
+	// Empty set
+	result := set.GetAny()
+	if result.IsSome() {
+		t.Error("Empty set should return None")
+	}
+
+	// Non-empty set
+	set.Add(42)
+	result = set.GetAny()
+	if result.IsNone() {
+		t.Error("Non-empty set should return Some")
+	}
+	if result.Unwrap() != 42 {
+		t.Errorf("Expected 42, got %d", result.Unwrap())
+	}
+}
+
+func testSetTryRemove(t *testing.T, factory func() setx.Set[uint]) {
+	set := factory()
+	set.Add(1)
+	set.Add(2)
+	set.Add(3)
+
+	// Remove existing element
+	result := set.TryRemove(2)
+	if result.IsErr() {
+		t.Errorf("Should successfully remove existing element: %v", result.UnwrapErr())
+	}
+	if result.Unwrap() != 2 {
+		t.Errorf("Expected removed element to be 2, got %d", result.Unwrap())
+	}
+
+	if set.Contains(2) {
+		t.Error("Element 2 should be removed from set")
+	}
+
+	// Try to remove non-existing element
+	result = set.TryRemove(10)
+	if result.IsOk() || !errors.Is(result.UnwrapErr(), setx.ErrElementNotFound) {
+		t.Error("Should fail to remove non-existing element with ErrElementNotFound")
+	}
+}
+
+func testSetFilter(t *testing.T, factory func() setx.Set[uint]) {
+	set := factory()
+	set.Add(1)
+	set.Add(2)
+	set.Add(3)
+	set.Add(4)
+	set.Add(5)
+
+	// Filter even numbers
+	evenSet := set.Filter(func(x uint) bool { return x%2 == 0 })
+
+	if evenSet.Size() != 2 {
+		t.Errorf("Expected 2 even numbers, got %d", evenSet.Size())
+	}
+
+	if !evenSet.Contains(2) || !evenSet.Contains(4) {
+		t.Error("Even set should contain 2 and 4")
+	}
+
+	if evenSet.Contains(1) || evenSet.Contains(3) || evenSet.Contains(5) {
+		t.Error("Even set should not contain odd numbers")
+	}
+
+	// Filter with no matches
+	largeSet := set.Filter(func(x uint) bool { return x > 10 })
+	if !largeSet.IsEmpty() {
+		t.Error("Filter with no matches should return empty set")
+	}
+}
@@ -1,9 +1,10 @@
 package concurrentset
 
 import (
-	"errors"
 	"sync"
+	"sync/atomic"
 
+	"github.com/gosuda/stdx/mapx/persistent"
 	"github.com/gosuda/stdx/option"
 	"github.com/gosuda/stdx/result"
 	"github.com/gosuda/stdx/setx"
@@ -11,176 +12,258 @@ import (
 
 var _ setx.Set[int] = (*ConcurrentSet[int])(nil)
 
+// ConcurrentSet is a thread-safe set that publishes immutable versions of a persistent hash trie.
+//
+// Every method is linearizable:
+//   - Contains, Size, IsEmpty, ToSlice, ForEach, Find, GetAny, Filter, IsSubsetOf, Union,
+//     Intersection, Difference and Snapshot atomically load the current version of the receiver
+//     and work on it alone. Set operations read other with its own methods, which are only
+//     linearizable if other is.
+//   - Add, Remove, TryRemove, Clear, AddAll, RetainAll, RemoveAll and SymmetricDifference
+//     are serialized and atomically publish a new version.
+//
+// Separate calls may observe different versions. Use Snapshot to read several properties
+// from the same point in time.
+//
+// All mutating methods, including the bulk ones, share one mutex, so a large AddAll
+// delays every other writer until it has published its version.
 type ConcurrentSet[T comparable] struct {
-	elements sync.Map
+	mu      sync.Mutex // serializes writers
+	current atomic.Pointer[persistent.Map[T, struct{}]]
 }
 
 func New[T comparable]() *ConcurrentSet[T] {
-	return &ConcurrentSet[T]{}
+	c := &ConcurrentSet[T]{}
+	c.current.Store(persistent.New[T, struct{}]())
+	return c
+}
+
+// load returns the current version, initializing the zero value on first use.
+func (c *ConcurrentSet[T]) load() *persistent.Map[T, struct{}] {
+	if m := c.current.Load(); m != nil {
+		return m
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.current.Load() == nil {
+		c.current.Store(persistent.New[T, struct{}]())
+	}
+	return c.current.Load()
+}
+
+// update atomically replaces the current version with the one returned by fn.
+func (c *ConcurrentSet[T]) update(fn func(*persistent.Map[T, struct{}]) *persistent.Map[T, struct{}]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	m := c.current.Load()
+	if m == nil {
+		m = persistent.New[T, struct{}]()
+	}
+	c.current.Store(fn(m))
+}
+
+// Snapshot returns an immutable point-in-time view of the set in O(1).
+// Later writes to the set are not visible through the snapshot.
+func (c *ConcurrentSet[T]) Snapshot() *Snapshot[T] {
+	return &Snapshot[T]{m: c.load()}
 }
 
 // Add implements setx.Set.
 func (c *ConcurrentSet[T]) Add(element T) bool {
-	_, loaded := c.elements.LoadOrStore(element, struct{}{})
-	return !loaded // true if element was not present before
+	added := false
+	c.update(func(m *persistent.Map[T, struct{}]) *persistent.Map[T, struct{}] {
+		if m.ContainsKey(element) {
+			return m
+		}
+		added = true
+		return m.Set(element, struct{}{})
+	})
+	return added
 }
 
 // Clear implements setx.Set.
 func (c *ConcurrentSet[T]) Clear() {
-	c.elements.Clear()
+	c.update(func(m *persistent.Map[T, struct{}]) *persistent.Map[T, struct{}] {
+		return m.Clear()
+	})
 }
 
 // Contains implements setx.Set.
 func (c *ConcurrentSet[T]) Contains(element T) bool {
-	_, exists := c.elements.Load(element)
-	return exists
+	return c.load().ContainsKey(element)
 }
 
 // Difference implements setx.Set.
 func (c *ConcurrentSet[T]) Difference(other setx.Set[T]) setx.Set[T] {
-	result := New[T]()
-	c.elements.Range(func(key, value any) bool {
-		element := key.(T)
-		if !other.Contains(element) {
-			result.Add(element)
-		}
-		return true
-	})
-	return result
+	return c.fromVersion(c.load().Filter(func(element T, _ struct{}) bool {
+		return !other.Contains(element)
+	}))
 }
 
-// ForEach implements setx.Set.
+// ForEach implements setx.Set. fn observes a single version and may modify the set.
 func (c *ConcurrentSet[T]) ForEach(fn func(element T)) {
-	c.elements.Range(func(key, value any) bool {
-		fn(key.(T))
-		return true
-	})
+	for element := range c.load().Keys() {
+		fn(element)
+	}
 }
 
-// Intersection implements setx.Set.
+// Intersection implements setx.Set. It iterates whichever of the two sets is smaller.
 func (c *ConcurrentSet[T]) Intersection(other setx.Set[T]) setx.Set[T] {
-	result := New[T]()
-	c.elements.Range(func(key, value any) bool {
-		element := key.(T)
-		if other.Contains(element) {
-			result.Add(element)
-		}
-		return true
-	})
-	return result
+	m := c.load()
+	if other.Size() < m.Size() {
+		b := persistent.NewBuilder[T, struct{}]()
+		other.ForEach(func(element T) {
+			if m.ContainsKey(element) {
+				b.Put(element, struct{}{})
+			}
+		})
+		return c.fromVersion(b.Map())
+	}
+	return c.fromVersion(m.Filter(func(element T, _ struct{}) bool {
+		return other.Contains(element)
+	}))
 }
 
 // IsEmpty implements setx.Set.
 func (c *ConcurrentSet[T]) IsEmpty() bool {
-	isEmpty := true
-	c.elements.Range(func(key, value any) bool {
-		isEmpty = false
-		return false // stop iteration
-	})
-	return isEmpty
+	return c.load().IsEmpty()
 }
 
 // IsSubsetOf implements setx.Set.
 func (c *ConcurrentSet[T]) IsSubsetOf(other setx.Set[T]) bool {
-	isSubset := true
-	c.elements.Range(func(key, value any) bool {
-		element := key.(T)
+	for element := range c.load().Keys() {
 		if !other.Contains(element) {
-			isSubset = false
-			return false // stop iteration
+			return false
 		}
-		return true
-	})
-	return isSubset
+	}
+	return true
 }
 
 // IsSupersetOf implements setx.Set.
 func (c *ConcurrentSet[T]) IsSupersetOf(other setx.Set[T]) bool {
-	return other.IsSubsetOf(c)
+	return other.IsSubsetOf(c.Snapshot())
 }
 
 // Remove implements setx.Set.
 func (c *ConcurrentSet[T]) Remove(element T) bool {
-	_, existed := c.elements.LoadAndDelete(element)
-	return existed
+	return c.TryRemove(element).IsOk()
 }
 
 // Size implements setx.Set.
 func (c *ConcurrentSet[T]) Size() int {
-	count := 0
-	c.elements.Range(func(key, value any) bool {
-		count++
-		return true
-	})
-	return count
+	return c.load().Size()
 }
 
 // ToSlice implements setx.Set.
 func (c *ConcurrentSet[T]) ToSlice() []T {
-	var result []T
-	c.elements.Range(func(key, value any) bool {
-		result = append(result, key.(T))
-		return true
-	})
-	return result
+	return c.Snapshot().ToSlice()
 }
 
 // Union implements setx.Set.
 func (c *ConcurrentSet[T]) Union(other setx.Set[T]) setx.Set[T] {
-	result := New[T]()
-	// Add all elements from current set
-	c.elements.Range(func(key, value any) bool {
-		result.Add(key.(T))
-		return true
-	})
-	// Add all elements from other set
+	b := c.load().Builder()
 	other.ForEach(func(element T) {
-		result.Add(element)
+		b.Put(element, struct{}{})
 	})
-	return result
+	return c.fromVersion(b.Map())
 }
 
 // Find implements setx.Set.
 func (c *ConcurrentSet[T]) Find(predicate func(T) bool) option.Option[T] {
-	var found option.Option[T] = option.None[T]()
-	c.elements.Range(func(key, value any) bool {
-		element := key.(T)
-		if predicate(element) {
-			found = option.Some(element)
-			return false // stop iteration
-		}
-		return true // continue iteration
-	})
-	return found
+	return c.Snapshot().Find(predicate)
 }
 
 // GetAny implements setx.Set.
 func (c *ConcurrentSet[T]) GetAny() option.Option[T] {
-	var found option.Option[T] = option.None[T]()
-	c.elements.Range(func(key, value any) bool {
-		found = option.Some(key.(T))
-		return false // stop after first element
-	})
-	return found
+	return c.Snapshot().GetAny()
 }
 
 // TryRemove implements setx.Set.
 func (c *ConcurrentSet[T]) TryRemove(element T) result.Result[T, error] {
-	if _, exists := c.elements.LoadAndDelete(element); exists {
+	removed := false
+	c.update(func(m *persistent.Map[T, struct{}]) *persistent.Map[T, struct{}] {
+		next, old := m.Remove(element)
+		removed = old.IsSome()
+		return next
+	})
+	if removed {
 		return result.Ok[T, error](element)
 	}
-	return result.Err[T, error](errors.New("element not found in set"))
+	return result.Err[T, error](setx.ErrElementNotFound)
 }
 
 // Filter implements setx.Set.
 func (c *ConcurrentSet[T]) Filter(predicate func(T) bool) setx.Set[T] {
-	result := New[T]()
-	c.elements.Range(func(key, value any) bool {
-		element := key.(T)
-		if predicate(element) {
-			result.Add(element)
+	return c.fromVersion(c.load().Filter(func(element T, _ struct{}) bool {
+		return predicate(element)
+	}))
+}
+
+// AddAll adds every element of other in a single atomic step and reports whether the set changed.
+// It is used by setx.AddAll.
+func (c *ConcurrentSet[T]) AddAll(other setx.Set[T]) bool {
+	elements := other.ToSlice()
+	changed := false
+	c.update(func(m *persistent.Map[T, struct{}]) *persistent.Map[T, struct{}] {
+		b := m.Builder()
+		for _, element := range elements {
+			b.Put(element, struct{}{})
+		}
+		changed = b.Size() != m.Size()
+		return b.Map()
+	})
+	return changed
+}
+
+// RetainAll removes every element not in other in a single atomic step and reports whether the set changed.
+// It is used by setx.RetainAll.
+func (c *ConcurrentSet[T]) RetainAll(other setx.Set[T]) bool {
+	changed := false
+	c.update(func(m *persistent.Map[T, struct{}]) *persistent.Map[T, struct{}] {
+		next := m.Filter(func(element T, _ struct{}) bool {
+			return other.Contains(element)
+		})
+		changed = next.Size() != m.Size()
+		return next
+	})
+	return changed
+}
+
+// RemoveAll removes every element of other in a single atomic step and reports whether the set changed.
+// It is used by setx.RemoveAll.
+func (c *ConcurrentSet[T]) RemoveAll(other setx.Set[T]) bool {
+	elements := other.ToSlice()
+	changed := false
+	c.update(func(m *persistent.Map[T, struct{}]) *persistent.Map[T, struct{}] {
+		b := m.Builder()
+		for _, element := range elements {
+			b.Remove(element)
 		}
-		return true
+		changed = b.Size() != m.Size()
+		return b.Map()
 	})
+	return changed
+}
+
+// SymmetricDifference replaces the set with the elements in exactly one of the set and other
+// in a single atomic step and reports whether the set changed. It is used by setx.SymmetricDifference.
+func (c *ConcurrentSet[T]) SymmetricDifference(other setx.Set[T]) bool {
+	elements := other.ToSlice()
+	c.update(func(m *persistent.Map[T, struct{}]) *persistent.Map[T, struct{}] {
+		b := m.Builder()
+		for _, element := range elements {
+			if b.Remove(element).IsNone() {
+				b.Put(element, struct{}{})
+			}
+		}
+		return b.Map()
+	})
+	return len(elements) > 0
+}
+
+// fromVersion creates a new ConcurrentSet starting at the given version.
+func (c *ConcurrentSet[T]) fromVersion(m *persistent.Map[T, struct{}]) *ConcurrentSet[T] {
+	result := &ConcurrentSet[T]{}
+	result.current.Store(m)
 	return result
 }
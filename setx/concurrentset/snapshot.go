@@ -0,0 +1,129 @@
+package concurrentset
+
+import (
+	"github.com/gosuda/stdx/mapx/persistent"
+	"github.com/gosuda/stdx/option"
+	"github.com/gosuda/stdx/result"
+	"github.com/gosuda/stdx/setx"
+)
+
+var _ setx.Set[int] = (*Snapshot[int])(nil)
+
+// Snapshot is an immutable point-in-time view of a ConcurrentSet.
+// Add, Remove and Clear panic with setx.ErrReadOnly and TryRemove returns Err(setx.ErrReadOnly).
+// Set operations return new snapshots.
+type Snapshot[T comparable] struct {
+	m *persistent.Map[T, struct{}]
+}
+
+// Add implements setx.Set. It always panics.
+func (s *Snapshot[T]) Add(element T) bool {
+	panic(setx.ErrReadOnly)
+}
+
+// Clear implements setx.Set. It always panics.
+func (s *Snapshot[T]) Clear() {
+	panic(setx.ErrReadOnly)
+}
+
+// Contains implements setx.Set.
+func (s *Snapshot[T]) Contains(element T) bool {
+	return s.m.ContainsKey(element)
+}
+
+// Difference implements setx.Set.
+func (s *Snapshot[T]) Difference(other setx.Set[T]) setx.Set[T] {
+	return s.Filter(func(element T) bool {
+		return !other.Contains(element)
+	})
+}
+
+// ForEach implements setx.Set.
+func (s *Snapshot[T]) ForEach(fn func(element T)) {
+	for element := range s.m.Keys() {
+		fn(element)
+	}
+}
+
+// Intersection implements setx.Set.
+func (s *Snapshot[T]) Intersection(other setx.Set[T]) setx.Set[T] {
+	return s.Filter(other.Contains)
+}
+
+// IsEmpty implements setx.Set.
+func (s *Snapshot[T]) IsEmpty() bool {
+	return s.m.IsEmpty()
+}
+
+// IsSubsetOf implements setx.Set.
+func (s *Snapshot[T]) IsSubsetOf(other setx.Set[T]) bool {
+	for element := range s.m.Keys() {
+		if !other.Contains(element) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSupersetOf implements setx.Set.
+func (s *Snapshot[T]) IsSupersetOf(other setx.Set[T]) bool {
+	return other.IsSubsetOf(s)
+}
+
+// Remove implements setx.Set. It always panics.
+func (s *Snapshot[T]) Remove(element T) bool {
+	panic(setx.ErrReadOnly)
+}
+
+// Size implements setx.Set.
+func (s *Snapshot[T]) Size() int {
+	return s.m.Size()
+}
+
+// ToSlice implements setx.Set.
+func (s *Snapshot[T]) ToSlice() []T {
+	result := make([]T, 0, s.m.Size())
+	for element := range s.m.Keys() {
+		result = append(result, element)
+	}
+	return result
+}
+
+// Union implements setx.Set.
+func (s *Snapshot[T]) Union(other setx.Set[T]) setx.Set[T] {
+	b := s.m.Builder()
+	other.ForEach(func(element T) {
+		b.Put(element, struct{}{})
+	})
+	return &Snapshot[T]{m: b.Map()}
+}
+
+// Find implements setx.Set.
+func (s *Snapshot[T]) Find(predicate func(T) bool) option.Option[T] {
+	for element := range s.m.Keys() {
+		if predicate(element) {
+			return option.Some(element)
+		}
+	}
+	return option.None[T]()
+}
+
+// GetAny implements setx.Set.
+func (s *Snapshot[T]) GetAny() option.Option[T] {
+	for element := range s.m.Keys() {
+		return option.Some(element)
+	}
+	return option.None[T]()
+}
+
+// TryRemove implements setx.Set. It always returns Err(setx.ErrReadOnly).
+func (s *Snapshot[T]) TryRemove(element T) result.Result[T, error] {
+	return result.Err[T, error](setx.ErrReadOnly)
+}
+
+// Filter implements setx.Set.
+func (s *Snapshot[T]) Filter(predicate func(T) bool) setx.Set[T] {
+	return &Snapshot[T]{m: s.m.Filter(func(element T, _ struct{}) bool {
+		return predicate(element)
+	})}
+}
@@ -0,0 +1,115 @@
+package concurrentset_test
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/gosuda/stdx/setx"
+	"github.com/gosuda/stdx/setx/concurrentset"
+)
+
+func TestConcurrentSet_Snapshot(t *testing.T) {
+	set := concurrentset.New[int]()
+	set.Add(1)
+	set.Add(2)
+	set.Add(3)
+
+	snap := set.Snapshot()
+	set.Add(4)
+	set.Remove(1)
+
+	if snap.Size() != 3 || !snap.Contains(1) || snap.Contains(4) {
+		t.Errorf("Snapshot should not observe later writes, got %v", snap.ToSlice())
+	}
+	if set.Size() != 3 || set.Contains(1) || !set.Contains(4) {
+		t.Error("Set should observe its own writes")
+	}
+
+	elements := snap.ToSlice()
+	sort.Ints(elements)
+	if len(elements) != 3 || elements[0] != 1 || elements[2] != 3 {
+		t.Errorf("Unexpected snapshot elements %v", elements)
+	}
+}
+
+func TestSnapshot_ReadOnly(t *testing.T) {
+	set := concurrentset.New[int]()
+	set.Add(1)
+	snap := set.Snapshot()
+
+	if err := snap.TryRemove(1).UnwrapErr(); !errors.Is(err, setx.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly, got %v", err)
+	}
+	for name, mutate := range map[string]func(){
+		"Add":    func() { snap.Add(2) },
+		"Remove": func() { snap.Remove(1) },
+		"Clear":  func() { snap.Clear() },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r != setx.ErrReadOnly {
+					t.Errorf("%s should panic with ErrReadOnly, got %v", name, r)
+				}
+			}()
+			mutate()
+		}()
+	}
+}
+
+func TestSnapshot_SetOperations(t *testing.T) {
+	a := concurrentset.New[int]()
+	b := concurrentset.New[int]()
+	for i := 1; i <= 4; i++ {
+		a.Add(i)
+		b.Add(i + 2)
+	}
+	snap := a.Snapshot()
+
+	if snap.Union(b).Size() != 6 {
+		t.Error("Union should contain 6 elements")
+	}
+	if snap.Intersection(b).Size() != 2 {
+		t.Error("Intersection should contain 2 elements")
+	}
+	if diff := snap.Difference(b); diff.Size() != 2 || !diff.Contains(1) {
+		t.Error("Difference should contain 1 and 2")
+	}
+	if !snap.IsSupersetOf(snap.Filter(func(x int) bool { return x > 2 })) {
+		t.Error("Snapshot should be a superset of its filtered subset")
+	}
+	if snap.IsSubsetOf(b) || snap.IsEmpty() {
+		t.Error("Snapshot should not be a subset of b")
+	}
+	if snap.Find(func(x int) bool { return x == 3 }).IsNone() || snap.GetAny().IsNone() {
+		t.Error("Find and GetAny should find elements")
+	}
+
+	count := 0
+	snap.ForEach(func(int) { count++ })
+	if count != 4 {
+		t.Errorf("Expected 4 elements, got %d", count)
+	}
+}
+
+func TestConcurrentSet_SnapshotConsistency(t *testing.T) {
+	set := concurrentset.New[int]()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			set.Add(i)
+		}
+	}()
+
+	for i := 0; i < 100; i++ {
+		snap := set.Snapshot()
+		if len(snap.ToSlice()) != snap.Size() {
+			t.Fatalf("Snapshot size %d does not match its elements", snap.Size())
+		}
+	}
+	wg.Wait()
+}
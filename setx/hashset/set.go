@@ -1,8 +1,6 @@
 package hashset
 
 import (
-	"errors"
-
 	"github.com/gosuda/stdx/option"
 	"github.com/gosuda/stdx/result"
 	"github.com/gosuda/stdx/setx"
@@ -51,9 +49,17 @@ func (h *HashSet[T]) Difference(other setx.Set[T]) setx.Set[T] {
 	return result
 }
 
-// Intersection implements setx.Set.
+// Intersection implements setx.Set. It iterates whichever of the two sets is smaller.
 func (h *HashSet[T]) Intersection(other setx.Set[T]) setx.Set[T] {
 	result := New[T]()
+	if other.Size() < len(h.elements) {
+		other.ForEach(func(element T) {
+			if h.Contains(element) {
+				result.Add(element)
+			}
+		})
+		return result
+	}
 	for element := range h.elements {
 		if other.Contains(element) {
 			result.Add(element)
@@ -150,7 +156,7 @@ func (h *HashSet[T]) TryRemove(element T) result.Result[T, error] {
 		delete(h.elements, element)
 		return result.Ok[T, error](element)
 	}
-	return result.Err[T, error](errors.New("element not found in set"))
+	return result.Err[T, error](setx.ErrElementNotFound)
 }
 
 // Filter implements setx.Set.
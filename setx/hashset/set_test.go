@@ -1,6 +1,7 @@
 package hashset_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/gosuda/stdx/setx"
@@ -441,8 +442,8 @@ func testSetTryRemove(t *testing.T, factory func() setx.Set[int]) {
 
 	// Try to remove non-existing element
 	result = set.TryRemove(10)
-	if result.IsOk() {
-		t.Error("Should fail to remove non-existing element")
+	if result.IsOk() || !errors.Is(result.UnwrapErr(), setx.ErrElementNotFound) {
+		t.Error("Should fail to remove non-existing element with ErrElementNotFound")
 	}
 }
 
@@ -0,0 +1,229 @@
+// Package linkedset provides a set that remembers the order in which elements were inserted.
+package linkedset
+
+import (
+	"iter"
+
+	"github.com/gosuda/stdx/option"
+	"github.com/gosuda/stdx/result"
+	"github.com/gosuda/stdx/setx"
+)
+
+var _ setx.Set[int] = (*LinkedSet[int])(nil)
+
+// node is an element of the insertion-order list.
+type node[T comparable] struct {
+	value      T
+	prev, next *node[T]
+}
+
+// LinkedSet is a hash set that iterates in insertion order.
+// Re-adding an existing element does not change its position.
+type LinkedSet[T comparable] struct {
+	elements map[T]*node[T]
+	head     *node[T]
+	tail     *node[T]
+}
+
+func New[T comparable]() *LinkedSet[T] {
+	return &LinkedSet[T]{
+		elements: make(map[T]*node[T]),
+	}
+}
+
+// unlink removes n from the insertion-order list.
+func (l *LinkedSet[T]) unlink(n *node[T]) {
+	if n.prev == nil {
+		l.head = n.next
+	} else {
+		n.prev.next = n.next
+	}
+	if n.next == nil {
+		l.tail = n.prev
+	} else {
+		n.next.prev = n.prev
+	}
+}
+
+// Add implements setx.Set.
+func (l *LinkedSet[T]) Add(element T) bool {
+	if _, exists := l.elements[element]; exists {
+		return false
+	}
+	n := &node[T]{value: element, prev: l.tail}
+	if l.tail == nil {
+		l.head = n
+	} else {
+		l.tail.next = n
+	}
+	l.tail = n
+	l.elements[element] = n
+	return true
+}
+
+// Clear implements setx.Set.
+func (l *LinkedSet[T]) Clear() {
+	l.elements = make(map[T]*node[T])
+	l.head = nil
+	l.tail = nil
+}
+
+// Contains implements setx.Set.
+func (l *LinkedSet[T]) Contains(element T) bool {
+	_, exists := l.elements[element]
+	return exists
+}
+
+// Difference implements setx.Set. The result keeps the receiver's order.
+func (l *LinkedSet[T]) Difference(other setx.Set[T]) setx.Set[T] {
+	return l.Filter(func(element T) bool {
+		return !other.Contains(element)
+	})
+}
+
+// Intersection implements setx.Set. The result keeps the receiver's order.
+func (l *LinkedSet[T]) Intersection(other setx.Set[T]) setx.Set[T] {
+	return l.Filter(other.Contains)
+}
+
+// IsEmpty implements setx.Set.
+func (l *LinkedSet[T]) IsEmpty() bool {
+	return len(l.elements) == 0
+}
+
+// IsSubsetOf implements setx.Set.
+func (l *LinkedSet[T]) IsSubsetOf(other setx.Set[T]) bool {
+	for n := l.head; n != nil; n = n.next {
+		if !other.Contains(n.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSupersetOf implements setx.Set.
+func (l *LinkedSet[T]) IsSupersetOf(other setx.Set[T]) bool {
+	return other.IsSubsetOf(l)
+}
+
+// Remove implements setx.Set.
+func (l *LinkedSet[T]) Remove(element T) bool {
+	n, exists := l.elements[element]
+	if !exists {
+		return false
+	}
+	l.unlink(n)
+	delete(l.elements, element)
+	return true
+}
+
+// Size implements setx.Set.
+func (l *LinkedSet[T]) Size() int {
+	return len(l.elements)
+}
+
+// ToSlice implements setx.Set. Elements are returned in insertion order.
+func (l *LinkedSet[T]) ToSlice() []T {
+	result := make([]T, 0, len(l.elements))
+	for n := l.head; n != nil; n = n.next {
+		result = append(result, n.value)
+	}
+	return result
+}
+
+// ForEach implements setx.Set. Elements are visited in insertion order.
+func (l *LinkedSet[T]) ForEach(fn func(element T)) {
+	for n := l.head; n != nil; n = n.next {
+		fn(n.value)
+	}
+}
+
+// Union implements setx.Set. Elements of the receiver come first, followed by new elements of other.
+func (l *LinkedSet[T]) Union(other setx.Set[T]) setx.Set[T] {
+	result := New[T]()
+	l.ForEach(func(element T) {
+		result.Add(element)
+	})
+	other.ForEach(func(element T) {
+		result.Add(element)
+	})
+	return result
+}
+
+// Find implements setx.Set. Returns the earliest inserted matching element.
+func (l *LinkedSet[T]) Find(predicate func(T) bool) option.Option[T] {
+	for n := l.head; n != nil; n = n.next {
+		if predicate(n.value) {
+			return option.Some(n.value)
+		}
+	}
+	return option.None[T]()
+}
+
+// GetAny implements setx.Set. Returns the earliest inserted element.
+func (l *LinkedSet[T]) GetAny() option.Option[T] {
+	return l.First()
+}
+
+// TryRemove implements setx.Set.
+func (l *LinkedSet[T]) TryRemove(element T) result.Result[T, error] {
+	if l.Remove(element) {
+		return result.Ok[T, error](element)
+	}
+	return result.Err[T, error](setx.ErrElementNotFound)
+}
+
+// Filter implements setx.Set. The result keeps the receiver's order.
+func (l *LinkedSet[T]) Filter(predicate func(T) bool) setx.Set[T] {
+	result := New[T]()
+	for n := l.head; n != nil; n = n.next {
+		if predicate(n.value) {
+			result.Add(n.value)
+		}
+	}
+	return result
+}
+
+// First returns the earliest inserted element, or None if the set is empty.
+func (l *LinkedSet[T]) First() option.Option[T] {
+	if l.head == nil {
+		return option.None[T]()
+	}
+	return option.Some(l.head.value)
+}
+
+// Last returns the latest inserted element, or None if the set is empty.
+func (l *LinkedSet[T]) Last() option.Option[T] {
+	if l.tail == nil {
+		return option.None[T]()
+	}
+	return option.Some(l.tail.value)
+}
+
+// All returns an iterator over the elements in insertion order.
+// The element being visited may be removed during iteration.
+func (l *LinkedSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := l.head; n != nil; {
+			next := n.next
+			if !yield(n.value) {
+				return
+			}
+			n = next
+		}
+	}
+}
+
+// Backward returns an iterator over the elements in reverse insertion order.
+// The element being visited may be removed during iteration.
+func (l *LinkedSet[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := l.tail; n != nil; {
+			prev := n.prev
+			if !yield(n.value) {
+				return
+			}
+			n = prev
+		}
+	}
+}
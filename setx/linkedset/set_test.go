@@ -0,0 +1,560 @@
+package linkedset_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/gosuda/stdx/setx"
+	"github.com/gosuda/stdx/setx/linkedset"
+)
+
+// createLinkedSet is a factory function for creating LinkedSet instances
+func createLinkedSet[T comparable]() setx.Set[T] {
+	return linkedset.New[T]()
+}
+
+func TestLinkedSet_Add(t *testing.T) {
+	testSetAdd(t, createLinkedSet[int])
+}
+
+func TestLinkedSet_Remove(t *testing.T) {
+	testSetRemove(t, createLinkedSet[int])
+}
+
+func TestLinkedSet_Contains(t *testing.T) {
+	testSetContains(t, createLinkedSet[int])
+}
+
+func TestLinkedSet_Size(t *testing.T) {
+	testSetSize(t, createLinkedSet[int])
+}
+
+func TestLinkedSet_IsEmpty(t *testing.T) {
+	testSetIsEmpty(t, createLinkedSet[int])
+}
+
+func TestLinkedSet_Clear(t *testing.T) {
+	testSetClear(t, createLinkedSet[int])
+}
+
+func TestLinkedSet_ToSlice(t *testing.T) {
+	testSetToSlice(t, createLinkedSet[int])
+}
+
+func TestLinkedSet_ForEach(t *testing.T) {
+	testSetForEach(t, createLinkedSet[int])
+}
+
+func TestLinkedSet_Union(t *testing.T) {
+	testSetUnion(t, createLinkedSet[int])
+}
+
+func TestLinkedSet_Intersection(t *testing.T) {
+	testSetIntersection(t, createLinkedSet[int])
+}
+
+func TestLinkedSet_Difference(t *testing.T) {
+	testSetDifference(t, createLinkedSet[int])
+}
+
+func TestLinkedSet_IsSubsetOf(t *testing.T) {
+	testSetIsSubsetOf(t, createLinkedSet[int])
+}
+
+func TestLinkedSet_IsSupersetOf(t *testing.T) {
+	testSetIsSupersetOf(t, createLinkedSet[int])
+}
+
+func TestLinkedSet_Find(t *testing.T) {
+	testSetFind(t, createLinkedSet[int])
+}
+
+func TestLinkedSet_GetAny(t *testing.T) {
+	testSetGetAny(t, createLinkedSet[int])
+}
+
+func TestLinkedSet_TryRemove(t *testing.T) {
+	testSetTryRemove(t, createLinkedSet[int])
+}
+
+func TestLinkedSet_Filter(t *testing.T) {
+	testSetFilter(t, createLinkedSet[int])
+}
+
+func TestLinkedSet_InsertionOrder(t *testing.T) {
+	set := linkedset.New[int]()
+	for _, v := range []int{5, 3, 9, 1, 3, 7} {
+		set.Add(v)
+	}
+	set.Remove(9)
+	set.Add(9)
+
+	expected := []int{5, 3, 1, 7, 9}
+	if got := set.ToSlice(); !slices.Equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+	if got := slices.Collect(set.All()); !slices.Equal(got, expected) {
+		t.Errorf("All: expected %v, got %v", expected, got)
+	}
+
+	backward := slices.Collect(set.Backward())
+	slices.Reverse(backward)
+	if !slices.Equal(backward, expected) {
+		t.Errorf("Backward should be the reverse of %v, got %v", expected, backward)
+	}
+
+	var visited []int
+	set.ForEach(func(v int) { visited = append(visited, v) })
+	if !slices.Equal(visited, expected) {
+		t.Errorf("ForEach: expected %v, got %v", expected, visited)
+	}
+}
+
+func TestLinkedSet_FirstLast(t *testing.T) {
+	set := linkedset.New[string]()
+	if set.First().IsSome() || set.Last().IsSome() {
+		t.Error("Empty set should have no first or last element")
+	}
+
+	set.Add("a")
+	set.Add("b")
+	set.Add("c")
+	if set.First().Unwrap() != "a" || set.Last().Unwrap() != "c" {
+		t.Errorf("Expected first a and last c, got %v and %v", set.First(), set.Last())
+	}
+
+	set.Remove("a")
+	set.Remove("c")
+	if set.First().Unwrap() != "b" || set.Last().Unwrap() != "b" {
+		t.Error("Single remaining element should be both first and last")
+	}
+}
+
+func TestLinkedSet_OrderedOperations(t *testing.T) {
+	a := linkedset.New[int]()
+	b := linkedset.New[int]()
+	for _, v := range []int{4, 2, 8, 6} {
+		a.Add(v)
+	}
+	for _, v := range []int{9, 6, 4, 1} {
+		b.Add(v)
+	}
+
+	if got := a.Union(b).ToSlice(); !slices.Equal(got, []int{4, 2, 8, 6, 9, 1}) {
+		t.Errorf("Unexpected union order %v", got)
+	}
+	if got := a.Intersection(b).ToSlice(); !slices.Equal(got, []int{4, 6}) {
+		t.Errorf("Unexpected intersection order %v", got)
+	}
+	if got := a.Difference(b).ToSlice(); !slices.Equal(got, []int{2, 8}) {
+		t.Errorf("Unexpected difference order %v", got)
+	}
+	if got := a.Find(func(v int) bool { return v > 5 }); got.Unwrap() != 8 {
+		t.Errorf("Find should return the earliest match, got %v", got)
+	}
+
+	// Removing the current element during iteration is allowed.
+	for v := range a.All() {
+		a.Remove(v)
+	}
+	if !a.IsEmpty() {
+		t.Error("Set should be empty after removing every element during iteration")
+	}
+}
+
+// Common test functions that can be reused for any Set implementation
+
+func testSetAdd(t *testing.T, factory func() setx.Set[int]) {
+	set := factory()
+
+	// Test adding new element
+	if !set.Add(1) {
+		t.Error("Add(1) should return true for new element")
+	}
+	if !set.Contains(1) {
+		t.Error("Set should contain 1 after adding")
+	}
+
+	// Test adding duplicate element
+	if set.Add(1) {
+		t.Error("Add(1) should return false for duplicate element")
+	}
+
+	// Test size after additions
+	set.Add(2)
+	set.Add(3)
+	if set.Size() != 3 {
+		t.Errorf("Expected size 3, got %d", set.Size())
+	}
+}
+
+func testSetRemove(t *testing.T, factory func() setx.Set[int]) {
+	set := factory()
+	set.Add(1)
+	set.Add(2)
+	set.Add(3)
+
+	// Test removing existing element
+	if !set.Remove(2) {
+		t.Error("Remove(2) should return true for existing element")
+	}
+	if set.Contains(2) {
+		t.Error("Set should not contain 2 after removal")
+	}
+
+	// Test removing non-existing element
+	if set.Remove(4) {
+		t.Error("Remove(4) should return false for non-existing element")
+	}
+
+	// Test size after removal
+	if set.Size() != 2 {
+		t.Errorf("Expected size 2, got %d", set.Size())
+	}
+}
+
+func testSetContains(t *testing.T, factory func() setx.Set[int]) {
+	set := factory()
+	set.Add(1)
+	set.Add(2)
+
+	if !set.Contains(1) {
+		t.Error("Set should contain 1")
+	}
+	if !set.Contains(2) {
+		t.Error("Set should contain 2")
+	}
+	if set.Contains(3) {
+		t.Error("Set should not contain 3")
+	}
+}
+
+func testSetSize(t *testing.T, factory func() setx.Set[int]) {
+	set := factory()
+
+	if set.Size() != 0 {
+		t.Errorf("Empty set size should be 0, got %d", set.Size())
+	}
+
+	set.Add(1)
+	if set.Size() != 1 {
+		t.Errorf("Size should be 1, got %d", set.Size())
+	}
+
+	set.Add(2)
+	set.Add(3)
+	if set.Size() != 3 {
+		t.Errorf("Size should be 3, got %d", set.Size())
+	}
+
+	set.Remove(2)
+	if set.Size() != 2 {
+		t.Errorf("Size should be 2 after removal, got %d", set.Size())
+	}
+}
+
+func testSetIsEmpty(t *testing.T, factory func() setx.Set[int]) {
+	set := factory()
+
+	if !set.IsEmpty() {
+		t.Error("New set should be empty")
+	}
+
+	set.Add(1)
+	if set.IsEmpty() {
+		t.Error("Set with elements should not be empty")
+	}
+
+	set.Remove(1)
+	if !set.IsEmpty() {
+		t.Error("Set should be empty after removing all elements")
+	}
+}
+
+func testSetClear(t *testing.T, factory func() setx.Set[int]) {
+	set := factory()
+	set.Add(1)
+	set.Add(2)
+	set.Add(3)
+
+	set.Clear()
+
+	if !set.IsEmpty() {
+		t.Error("Set should be empty after Clear()")
+	}
+	if set.Size() != 0 {
+		t.Errorf("Size should be 0 after Clear(), got %d", set.Size())
+	}
+	if set.Contains(1) || set.Contains(2) || set.Contains(3) {
+		t.Error("Set should not contain any elements after Clear()")
+	}
+}
+
+func testSetToSlice(t *testing.T, factory func() setx.Set[int]) {
+	set := factory()
+	set.Add(1)
+	set.Add(2)
+	set.Add(3)
+
+	slice := set.ToSlice()
+
+	if len(slice) != 3 {
+		t.Errorf("Expected slice length 3, got %d", len(slice))
+	}
+
+	// Check all elements are present (order doesn't matter)
+	found := make(map[int]bool)
+	for _, v := range slice {
+		found[v] = true
+	}
+
+	if !found[1] || !found[2] || !found[3] {
+		t.Error("ToSlice() should contain all set elements")
+	}
+}
+
+func testSetForEach(t *testing.T, factory func() setx.Set[int]) {
+	set := factory()
+	set.Add(1)
+	set.Add(2)
+	set.Add(3)
+
+	visited := make(map[int]bool)
+	set.ForEach(func(element int) {
+		visited[element] = true
+	})
+
+	if len(visited) != 3 {
+		t.Errorf("Expected to visit 3 elements, visited %d", len(visited))
+	}
+
+	if !visited[1] || !visited[2] || !visited[3] {
+		t.Error("ForEach should visit all elements")
+	}
+}
+
+func testSetUnion(t *testing.T, factory func() setx.Set[int]) {
+	set1 := factory()
+	set2 := factory()
+
+	set1.Add(1)
+	set1.Add(2)
+	set2.Add(2)
+	set2.Add(3)
+
+	union := set1.Union(set2)
+
+	if union.Size() != 3 {
+		t.Errorf("Union size should be 3, got %d", union.Size())
+	}
+
+	if !union.Contains(1) || !union.Contains(2) || !union.Contains(3) {
+		t.Error("Union should contain elements from both sets")
+	}
+}
+
+func testSetIntersection(t *testing.T, factory func() setx.Set[int]) {
+	set1 := factory()
+	set2 := factory()
+
+	set1.Add(1)
+	set1.Add(2)
+	set1.Add(3)
+	set2.Add(2)
+	set2.Add(3)
+	set2.Add(4)
+
+	intersection := set1.Intersection(set2)
+
+	if intersection.Size() != 2 {
+		t.Errorf("Intersection size should be 2, got %d", intersection.Size())
+	}
+
+	if !intersection.Contains(2) || !intersection.Contains(3) {
+		t.Error("Intersection should contain common elements")
+	}
+
+	if intersection.Contains(1) || intersection.Contains(4) {
+		t.Error("Intersection should not contain non-common elements")
+	}
+}
+
+func testSetDifference(t *testing.T, factory func() setx.Set[int]) {
+	set1 := factory()
+	set2 := factory()
+
+	set1.Add(1)
+	set1.Add(2)
+	set1.Add(3)
+	set2.Add(2)
+	set2.Add(4)
+
+	difference := set1.Difference(set2)
+
+	if difference.Size() != 2 {
+		t.Errorf("Difference size should be 2, got %d", difference.Size())
+	}
+
+	if !difference.Contains(1) || !difference.Contains(3) {
+		t.Error("Difference should contain elements only in first set")
+	}
+
+	if difference.Contains(2) || difference.Contains(4) {
+		t.Error("Difference should not contain common or second set only elements")
+	}
+}
+
+func testSetIsSubsetOf(t *testing.T, factory func() setx.Set[int]) {
+	set1 := factory()
+	set2 := factory()
+
+	set1.Add(1)
+	set1.Add(2)
+	set2.Add(1)
+	set2.Add(2)
+	set2.Add(3)
+
+	if !set1.IsSubsetOf(set2) {
+		t.Error("set1 should be subset of set2")
+	}
+
+	if set2.IsSubsetOf(set1) {
+		t.Error("set2 should not be subset of set1")
+	}
+
+	// Test with empty set
+	emptySet := factory()
+	if !emptySet.IsSubsetOf(set1) {
+		t.Error("Empty set should be subset of any set")
+	}
+}
+
+func testSetIsSupersetOf(t *testing.T, factory func() setx.Set[int]) {
+	set1 := factory()
+	set2 := factory()
+
+	set1.Add(1)
+	set1.Add(2)
+	set1.Add(3)
+	set2.Add(1)
+	set2.Add(2)
+
+	if !set1.IsSupersetOf(set2) {
+		t.Error("set1 should be superset of set2")
+	}
+
+	if set2.IsSupersetOf(set1) {
+		t.Error("set2 should not be superset of set1")
+	}
+
+	// Test with empty set
+	emptySet := factory()
+	if !set1.IsSupersetOf(emptySet) {
+		t.Error("Any set should be superset of empty set")
+	}
+}
+
+// Test functions for new Option/Result-based methods
+
+func testSetFind(t *testing.T, factory func() setx.Set[int]) {
+	set := factory()
+	set.Add(1)
+	set.Add(2)
+	set.Add(3)
+	set.Add(4)
+	set.Add(5)
+
+	// Find even number
+	result := set.Find(func(x int) bool { return x%2 == 0 })
+	if result.IsNone() {
+		t.Error("Should find an even number")
+	}
+
+	value := result.Unwrap()
+	if value%2 != 0 {
+		t.Errorf("Found value should be even, got %d", value)
+	}
+
+	// Find number greater than 10 (should not exist)
+	result = set.Find(func(x int) bool { return x > 10 })
+	if result.IsSome() {
+		t.Error("Should not find number greater than 10")
+	}
+}
+
+func testSetGetAny(t *testing.T, factory func() setx.Set[int]) {
+	set := factory()
+
+	// Empty set
+	result := set.GetAny()
+	if result.IsSome() {
+		t.Error("Empty set should return None")
+	}
+
+	// Non-empty set
+	set.Add(42)
+	result = set.GetAny()
+	if result.IsNone() {
+		t.Error("Non-empty set should return Some")
+	}
+	if result.Unwrap() != 42 {
+		t.Errorf("Expected 42, got %d", result.Unwrap())
+	}
+}
+
+func testSetTryRemove(t *testing.T, factory func() setx.Set[int]) {
+	set := factory()
+	set.Add(1)
+	set.Add(2)
+	set.Add(3)
+
+	// Remove existing element
+	result := set.TryRemove(2)
+	if result.IsErr() {
+		t.Errorf("Should successfully remove existing element: %v", result.UnwrapErr())
+	}
+	if result.Unwrap() != 2 {
+		t.Errorf("Expected removed element to be 2, got %d", result.Unwrap())
+	}
+
+	if set.Contains(2) {
+		t.Error("Element 2 should be removed from set")
+	}
+
+	// Try to remove non-existing element
+	result = set.TryRemove(10)
+	if result.IsOk() || !errors.Is(result.UnwrapErr(), setx.ErrElementNotFound) {
+		t.Error("Should fail to remove non-existing element with ErrElementNotFound")
+	}
+}
+
+func testSetFilter(t *testing.T, factory func() setx.Set[int]) {
+	set := factory()
+	set.Add(1)
+	set.Add(2)
+	set.Add(3)
+	set.Add(4)
+	set.Add(5)
+
+	// Filter even numbers
+	evenSet := set.Filter(func(x int) bool { return x%2 == 0 })
+
+	if evenSet.Size() != 2 {
+		t.Errorf("Expected 2 even numbers, got %d", evenSet.Size())
+	}
+
+	if !evenSet.Contains(2) || !evenSet.Contains(4) {
+		t.Error("Even set should contain 2 and 4")
+	}
+
+	if evenSet.Contains(1) || evenSet.Contains(3) || evenSet.Contains(5) {
+		t.Error("Even set should not contain odd numbers")
+	}
+
+	// Filter with no matches
+	largeSet := set.Filter(func(x int) bool { return x > 10 })
+	if !largeSet.IsEmpty() {
+		t.Error("Filter with no matches should return empty set")
+	}
+}
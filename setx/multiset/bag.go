@@ -0,0 +1,104 @@
+// Package multiset provides bags: sets that count how many times each element occurs.
+package multiset
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/gosuda/stdx/setx"
+)
+
+// Bag is a collection that counts occurrences of its elements.
+// Counts are never negative; an element with count 0 is not in the bag.
+type Bag[T comparable] interface {
+	// Add adds n occurrences of element and returns its previous count. Panics if n is negative.
+	Add(element T, n int) int
+
+	// Remove removes up to n occurrences of element and returns its previous count. Panics if n is negative.
+	Remove(element T, n int) int
+
+	// SetCount sets the count of element to n and returns its previous count. Panics if n is negative.
+	SetCount(element T, n int) int
+
+	// Count returns the number of occurrences of element.
+	Count(element T) int
+
+	// Contains reports whether element occurs at least once.
+	Contains(element T) bool
+
+	// Size returns the total number of occurrences of all elements.
+	Size() int
+
+	// IsEmpty reports whether the bag has no elements.
+	IsEmpty() bool
+
+	// Clear removes all elements.
+	Clear()
+
+	// Distinct returns a live set view of the elements with a non-zero count.
+	// Adding to the view adds one occurrence; removing from it removes all occurrences.
+	Distinct() setx.Set[T]
+
+	// ForEach calls fn for every distinct element with its count.
+	ForEach(fn func(element T, count int))
+
+	// MostCommon returns the k elements with the highest counts in descending order of count.
+	// Elements with equal counts are returned in unspecified order. A negative k returns all elements.
+	MostCommon(k int) []Entry[T]
+
+	// Sum returns a new bag in which each count is the sum of the counts in both bags.
+	Sum(other Bag[T]) Bag[T]
+
+	// Union returns a new bag in which each count is the maximum of the counts in both bags.
+	Union(other Bag[T]) Bag[T]
+
+	// Intersection returns a new bag in which each count is the minimum of the counts in both bags.
+	Intersection(other Bag[T]) Bag[T]
+
+	// Difference returns a new bag in which each count is the count in this bag minus the count
+	// in other, or zero if that is negative.
+	Difference(other Bag[T]) Bag[T]
+}
+
+// Entry is an element of a bag with its count.
+type Entry[T comparable] struct {
+	Element T
+	Count   int
+}
+
+// checkCount panics if n is negative.
+func checkCount(n int) {
+	if n < 0 {
+		panic("multiset: negative count")
+	}
+}
+
+// mostCommon sorts entries by descending count and returns the first k, or all if k is negative.
+func mostCommon[T comparable](entries []Entry[T], k int) []Entry[T] {
+	slices.SortFunc(entries, func(a, b Entry[T]) int {
+		return cmp.Compare(b.Count, a.Count)
+	})
+	if k >= 0 && k < len(entries) {
+		entries = entries[:k]
+	}
+	return entries
+}
+
+// combine stores in dst the result of fn applied to the counts of every element of a or b and returns dst.
+func combine[T comparable](dst, a, b Bag[T], fn func(x, y int) int) Bag[T] {
+	a.ForEach(func(element T, count int) {
+		dst.SetCount(element, fn(count, b.Count(element)))
+	})
+	b.ForEach(func(element T, count int) {
+		if !a.Contains(element) {
+			dst.SetCount(element, fn(0, count))
+		}
+	})
+	return dst
+}
+
+// Count combiners for the bag algebra.
+func sumCount(x, y int) int      { return x + y }
+func maxCount(x, y int) int      { return max(x, y) }
+func minCount(x, y int) int      { return min(x, y) }
+func subtractCount(x, y int) int { return max(x-y, 0) }
@@ -0,0 +1,138 @@
+package multiset
+
+import (
+	"sync"
+
+	"github.com/gosuda/stdx/setx"
+)
+
+var _ Bag[int] = (*ConcurrentBag[int])(nil)
+
+// ConcurrentBag is a thread-safe Bag. Every method is linearizable; ForEach, MostCommon and
+// the bag algebra work on a copy taken atomically, so fn may modify the bag.
+//
+// Counts change in place under a mutex, which suits frequently incremented counters
+// better than copy-on-write.
+type ConcurrentBag[T comparable] struct {
+	mu  sync.RWMutex
+	bag *HashBag[T]
+}
+
+func NewConcurrent[T comparable]() *ConcurrentBag[T] {
+	return &ConcurrentBag[T]{bag: New[T]()}
+}
+
+// snapshot returns a copy of the current contents.
+func (c *ConcurrentBag[T]) snapshot() *HashBag[T] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.bag.clone()
+}
+
+// Add implements Bag.
+func (c *ConcurrentBag[T]) Add(element T, n int) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.bag.Add(element, n)
+}
+
+// Remove implements Bag.
+func (c *ConcurrentBag[T]) Remove(element T, n int) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.bag.Remove(element, n)
+}
+
+// SetCount implements Bag.
+func (c *ConcurrentBag[T]) SetCount(element T, n int) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.bag.SetCount(element, n)
+}
+
+// Count implements Bag.
+func (c *ConcurrentBag[T]) Count(element T) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.bag.Count(element)
+}
+
+// Contains implements Bag.
+func (c *ConcurrentBag[T]) Contains(element T) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.bag.Contains(element)
+}
+
+// Size implements Bag.
+func (c *ConcurrentBag[T]) Size() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.bag.Size()
+}
+
+// IsEmpty implements Bag.
+func (c *ConcurrentBag[T]) IsEmpty() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.bag.IsEmpty()
+}
+
+// distinctCount returns the number of distinct elements.
+func (c *ConcurrentBag[T]) distinctCount() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.bag.distinctCount()
+}
+
+// Clear implements Bag.
+func (c *ConcurrentBag[T]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.bag.Clear()
+}
+
+// Distinct implements Bag.
+func (c *ConcurrentBag[T]) Distinct() setx.Set[T] {
+	return &distinct[T]{bag: c}
+}
+
+// ForEach implements Bag.
+func (c *ConcurrentBag[T]) ForEach(fn func(element T, count int)) {
+	c.snapshot().ForEach(fn)
+}
+
+// MostCommon implements Bag.
+func (c *ConcurrentBag[T]) MostCommon(k int) []Entry[T] {
+	return c.snapshot().MostCommon(k)
+}
+
+// Sum implements Bag. The result is a new ConcurrentBag.
+func (c *ConcurrentBag[T]) Sum(other Bag[T]) Bag[T] {
+	return c.combine(other, sumCount)
+}
+
+// Union implements Bag. The result is a new ConcurrentBag.
+func (c *ConcurrentBag[T]) Union(other Bag[T]) Bag[T] {
+	return c.combine(other, maxCount)
+}
+
+// Intersection implements Bag. The result is a new ConcurrentBag.
+func (c *ConcurrentBag[T]) Intersection(other Bag[T]) Bag[T] {
+	return c.combine(other, minCount)
+}
+
+// Difference implements Bag. The result is a new ConcurrentBag.
+func (c *ConcurrentBag[T]) Difference(other Bag[T]) Bag[T] {
+	return c.combine(other, subtractCount)
+}
+
+// combine applies fn to a snapshot of c and other and wraps the result in a new ConcurrentBag.
+func (c *ConcurrentBag[T]) combine(other Bag[T], fn func(x, y int) int) Bag[T] {
+	if o, ok := other.(*ConcurrentBag[T]); ok {
+		other = o.snapshot()
+	}
+	result := New[T]()
+	combine(result, c.snapshot(), other, fn)
+	return &ConcurrentBag[T]{bag: result}
+}
@@ -0,0 +1,52 @@
+package multiset_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/gosuda/stdx/setx/multiset"
+)
+
+func TestConcurrentBag(t *testing.T) {
+	runBagTests(t, func() multiset.Bag[string] { return multiset.NewConcurrent[string]() })
+}
+
+func TestConcurrentBag_ConcurrentAdd(t *testing.T) {
+	b := multiset.NewConcurrent[int]()
+	const goroutines, perGoroutine = 8, 1000
+
+	var wg sync.WaitGroup
+	for g := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range perGoroutine {
+				b.Add(i%10, 1)
+				b.Count(g)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if b.Size() != goroutines*perGoroutine {
+		t.Errorf("Expected size %d, got %d", goroutines*perGoroutine, b.Size())
+	}
+	for i := range 10 {
+		if b.Count(i) != goroutines*perGoroutine/10 {
+			t.Errorf("Expected count %d for %d, got %d", goroutines*perGoroutine/10, i, b.Count(i))
+		}
+	}
+}
+
+func TestConcurrentBag_ForEachMayModify(t *testing.T) {
+	b := multiset.NewConcurrent[int]()
+	b.Add(1, 2)
+	b.Add(2, 3)
+
+	b.ForEach(func(element int, count int) {
+		b.Remove(element, count)
+	})
+	if !b.IsEmpty() {
+		t.Error("ForEach callback should be able to modify the bag")
+	}
+}
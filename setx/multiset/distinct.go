@@ -0,0 +1,150 @@
+package multiset
+
+import (
+	"github.com/gosuda/stdx/option"
+	"github.com/gosuda/stdx/result"
+	"github.com/gosuda/stdx/setx"
+	"github.com/gosuda/stdx/setx/hashset"
+)
+
+var _ setx.Set[int] = (*distinct[int])(nil)
+
+// distinctBag is a Bag that can count its distinct elements in constant time.
+type distinctBag[T comparable] interface {
+	Bag[T]
+	distinctCount() int
+}
+
+// distinct is the set view returned by Bag.Distinct. Set operations and Filter return new hash sets.
+type distinct[T comparable] struct {
+	bag distinctBag[T]
+}
+
+// elements returns the distinct elements of the bag.
+func (d *distinct[T]) elements() []T {
+	var elements []T
+	d.bag.ForEach(func(element T, _ int) {
+		elements = append(elements, element)
+	})
+	return elements
+}
+
+// Add implements setx.Set. It adds one occurrence if element is not in the bag.
+func (d *distinct[T]) Add(element T) bool {
+	if d.bag.Contains(element) {
+		return false
+	}
+	return d.bag.Add(element, 1) == 0
+}
+
+// Remove implements setx.Set. It removes all occurrences of element.
+func (d *distinct[T]) Remove(element T) bool {
+	return d.bag.SetCount(element, 0) > 0
+}
+
+// Clear implements setx.Set. It clears the bag.
+func (d *distinct[T]) Clear() {
+	d.bag.Clear()
+}
+
+// Contains implements setx.Set.
+func (d *distinct[T]) Contains(element T) bool {
+	return d.bag.Contains(element)
+}
+
+// Size implements setx.Set.
+func (d *distinct[T]) Size() int {
+	return d.bag.distinctCount()
+}
+
+// IsEmpty implements setx.Set.
+func (d *distinct[T]) IsEmpty() bool {
+	return d.bag.IsEmpty()
+}
+
+// ToSlice implements setx.Set.
+func (d *distinct[T]) ToSlice() []T {
+	return d.elements()
+}
+
+// ForEach implements setx.Set.
+func (d *distinct[T]) ForEach(fn func(element T)) {
+	for _, element := range d.elements() {
+		fn(element)
+	}
+}
+
+// Union implements setx.Set.
+func (d *distinct[T]) Union(other setx.Set[T]) setx.Set[T] {
+	result := d.copy()
+	other.ForEach(func(element T) {
+		result.Add(element)
+	})
+	return result
+}
+
+// Intersection implements setx.Set.
+func (d *distinct[T]) Intersection(other setx.Set[T]) setx.Set[T] {
+	return d.Filter(other.Contains)
+}
+
+// Difference implements setx.Set.
+func (d *distinct[T]) Difference(other setx.Set[T]) setx.Set[T] {
+	return d.Filter(func(element T) bool {
+		return !other.Contains(element)
+	})
+}
+
+// IsSubsetOf implements setx.Set.
+func (d *distinct[T]) IsSubsetOf(other setx.Set[T]) bool {
+	for _, element := range d.elements() {
+		if !other.Contains(element) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSupersetOf implements setx.Set.
+func (d *distinct[T]) IsSupersetOf(other setx.Set[T]) bool {
+	return other.IsSubsetOf(d)
+}
+
+// Find implements setx.Set.
+func (d *distinct[T]) Find(predicate func(T) bool) option.Option[T] {
+	for _, element := range d.elements() {
+		if predicate(element) {
+			return option.Some(element)
+		}
+	}
+	return option.None[T]()
+}
+
+// GetAny implements setx.Set.
+func (d *distinct[T]) GetAny() option.Option[T] {
+	return d.Find(func(T) bool { return true })
+}
+
+// TryRemove implements setx.Set.
+func (d *distinct[T]) TryRemove(element T) result.Result[T, error] {
+	if d.Remove(element) {
+		return result.Ok[T, error](element)
+	}
+	return result.Err[T, error](setx.ErrElementNotFound)
+}
+
+// Filter implements setx.Set.
+func (d *distinct[T]) Filter(predicate func(T) bool) setx.Set[T] {
+	result := hashset.New[T]()
+	for _, element := range d.elements() {
+		if predicate(element) {
+			result.Add(element)
+		}
+	}
+	return result
+}
+
+// copy returns the distinct elements as a new hash set.
+func (d *distinct[T]) copy() setx.Set[T] {
+	return d.Filter(func(T) bool { return true })
+}
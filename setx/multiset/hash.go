@@ -0,0 +1,137 @@
+package multiset
+
+import (
+	"github.com/gosuda/stdx/setx"
+)
+
+var _ Bag[int] = (*HashBag[int])(nil)
+
+// HashBag is a Bag backed by a map from element to count. It is not safe for concurrent use.
+type HashBag[T comparable] struct {
+	counts map[T]int
+	size   int
+}
+
+func New[T comparable]() *HashBag[T] {
+	return &HashBag[T]{
+		counts: make(map[T]int),
+	}
+}
+
+// Of creates a HashBag with one occurrence of each given element, so repeated elements are counted.
+func Of[T comparable](elements ...T) *HashBag[T] {
+	b := New[T]()
+	for _, element := range elements {
+		b.Add(element, 1)
+	}
+	return b
+}
+
+// Add implements Bag.
+func (h *HashBag[T]) Add(element T, n int) int {
+	checkCount(n)
+	previous := h.counts[element]
+	return h.SetCount(element, previous+n)
+}
+
+// Remove implements Bag.
+func (h *HashBag[T]) Remove(element T, n int) int {
+	checkCount(n)
+	previous := h.counts[element]
+	return h.SetCount(element, max(previous-n, 0))
+}
+
+// SetCount implements Bag.
+func (h *HashBag[T]) SetCount(element T, n int) int {
+	checkCount(n)
+	previous := h.counts[element]
+	if n == 0 {
+		delete(h.counts, element)
+	} else {
+		h.counts[element] = n
+	}
+	h.size += n - previous
+	return previous
+}
+
+// Count implements Bag.
+func (h *HashBag[T]) Count(element T) int {
+	return h.counts[element]
+}
+
+// Contains implements Bag.
+func (h *HashBag[T]) Contains(element T) bool {
+	_, exists := h.counts[element]
+	return exists
+}
+
+// Size implements Bag.
+func (h *HashBag[T]) Size() int {
+	return h.size
+}
+
+// IsEmpty implements Bag.
+func (h *HashBag[T]) IsEmpty() bool {
+	return h.size == 0
+}
+
+// distinctCount returns the number of distinct elements.
+func (h *HashBag[T]) distinctCount() int {
+	return len(h.counts)
+}
+
+// Clear implements Bag.
+func (h *HashBag[T]) Clear() {
+	h.counts = make(map[T]int)
+	h.size = 0
+}
+
+// Distinct implements Bag.
+func (h *HashBag[T]) Distinct() setx.Set[T] {
+	return &distinct[T]{bag: h}
+}
+
+// ForEach implements Bag.
+func (h *HashBag[T]) ForEach(fn func(element T, count int)) {
+	for element, count := range h.counts {
+		fn(element, count)
+	}
+}
+
+// MostCommon implements Bag.
+func (h *HashBag[T]) MostCommon(k int) []Entry[T] {
+	entries := make([]Entry[T], 0, len(h.counts))
+	for element, count := range h.counts {
+		entries = append(entries, Entry[T]{Element: element, Count: count})
+	}
+	return mostCommon(entries, k)
+}
+
+// Sum implements Bag. The result is a new HashBag.
+func (h *HashBag[T]) Sum(other Bag[T]) Bag[T] {
+	return combine(New[T](), h, other, sumCount)
+}
+
+// Union implements Bag. The result is a new HashBag.
+func (h *HashBag[T]) Union(other Bag[T]) Bag[T] {
+	return combine(New[T](), h, other, maxCount)
+}
+
+// Intersection implements Bag. The result is a new HashBag.
+func (h *HashBag[T]) Intersection(other Bag[T]) Bag[T] {
+	return combine(New[T](), h, other, minCount)
+}
+
+// Difference implements Bag. The result is a new HashBag.
+func (h *HashBag[T]) Difference(other Bag[T]) Bag[T] {
+	return combine(New[T](), h, other, subtractCount)
+}
+
+// clone returns an independent copy of h.
+func (h *HashBag[T]) clone() *HashBag[T] {
+	counts := make(map[T]int, len(h.counts))
+	for element, count := range h.counts {
+		counts[element] = count
+	}
+	return &HashBag[T]{counts: counts, size: h.size}
+}
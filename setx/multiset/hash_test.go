@@ -0,0 +1,218 @@
+package multiset_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/gosuda/stdx/setx/hashset"
+	"github.com/gosuda/stdx/setx/multiset"
+)
+
+func sorted(elements []string) []string {
+	slices.Sort(elements)
+	return elements
+}
+
+func bagOf(newBag func() multiset.Bag[string], elements ...string) multiset.Bag[string] {
+	b := newBag()
+	for _, element := range elements {
+		b.Add(element, 1)
+	}
+	return b
+}
+
+func counts(b multiset.Bag[string]) map[string]int {
+	m := make(map[string]int)
+	b.ForEach(func(element string, count int) {
+		m[element] = count
+	})
+	return m
+}
+
+func testBagCounts(t *testing.T, newBag func() multiset.Bag[string]) {
+	b := newBag()
+	if !b.IsEmpty() || b.Size() != 0 {
+		t.Error("New bag should be empty")
+	}
+
+	if previous := b.Add("a", 3); previous != 0 {
+		t.Errorf("Expected previous count 0, got %d", previous)
+	}
+	if previous := b.Add("a", 2); previous != 3 {
+		t.Errorf("Expected previous count 3, got %d", previous)
+	}
+	b.Add("b", 1)
+	b.Add("c", 0)
+	if b.Count("a") != 5 || b.Count("b") != 1 || b.Size() != 6 {
+		t.Errorf("Unexpected counts %v with size %d", counts(b), b.Size())
+	}
+	if b.Contains("c") {
+		t.Error("Adding zero occurrences should not add the element")
+	}
+
+	if previous := b.Remove("a", 2); previous != 5 || b.Count("a") != 3 {
+		t.Errorf("Remove returned %d and left count %d", previous, b.Count("a"))
+	}
+	if previous := b.Remove("b", 10); previous != 1 || b.Contains("b") {
+		t.Error("Removing more occurrences than present should remove the element")
+	}
+	if previous := b.Remove("missing", 1); previous != 0 {
+		t.Errorf("Expected previous count 0 for missing element, got %d", previous)
+	}
+
+	if previous := b.SetCount("a", 7); previous != 3 || b.Count("a") != 7 {
+		t.Error("SetCount should replace the count")
+	}
+	b.SetCount("a", 0)
+	if b.Contains("a") || !b.IsEmpty() || b.Size() != 0 {
+		t.Error("SetCount to zero should remove the element")
+	}
+
+	b.Add("x", 4)
+	b.Clear()
+	if !b.IsEmpty() || b.Count("x") != 0 {
+		t.Error("Clear should remove all elements")
+	}
+}
+
+func testBagNegativeCount(t *testing.T, newBag func() multiset.Bag[string]) {
+	ops := map[string]func(b multiset.Bag[string]){
+		"Add":      func(b multiset.Bag[string]) { b.Add("a", -1) },
+		"Remove":   func(b multiset.Bag[string]) { b.Remove("a", -1) },
+		"SetCount": func(b multiset.Bag[string]) { b.SetCount("a", -1) },
+	}
+	for name, op := range ops {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("Expected panic on negative count")
+				}
+			}()
+			op(newBag())
+		})
+	}
+}
+
+func testBagMostCommon(t *testing.T, newBag func() multiset.Bag[string]) {
+	b := bagOf(newBag, "the", "cat", "the", "dog", "the", "cat")
+
+	top := b.MostCommon(2)
+	want := []multiset.Entry[string]{{Element: "the", Count: 3}, {Element: "cat", Count: 2}}
+	if !slices.Equal(top, want) {
+		t.Errorf("Expected %v, got %v", want, top)
+	}
+	if all := b.MostCommon(-1); len(all) != 3 {
+		t.Errorf("Negative k should return all elements, got %v", all)
+	}
+	if more := b.MostCommon(10); len(more) != 3 {
+		t.Errorf("k larger than the bag should return all elements, got %v", more)
+	}
+	if none := b.MostCommon(0); len(none) != 0 {
+		t.Errorf("Expected no elements for k = 0, got %v", none)
+	}
+}
+
+func testBagAlgebra(t *testing.T, newBag func() multiset.Bag[string]) {
+	a := bagOf(newBag, "x", "x", "x", "y")
+	b := bagOf(newBag, "x", "y", "y", "z")
+
+	tests := []struct {
+		name string
+		got  multiset.Bag[string]
+		want map[string]int
+	}{
+		{"Sum", a.Sum(b), map[string]int{"x": 4, "y": 3, "z": 1}},
+		{"Union", a.Union(b), map[string]int{"x": 3, "y": 2, "z": 1}},
+		{"Intersection", a.Intersection(b), map[string]int{"x": 1, "y": 1}},
+		{"Difference", a.Difference(b), map[string]int{"x": 2}},
+	}
+	for _, tt := range tests {
+		got := counts(tt.got)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+			continue
+		}
+		size := 0
+		for element, count := range tt.want {
+			if got[element] != count {
+				t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+			}
+			size += count
+		}
+		if tt.got.Size() != size {
+			t.Errorf("%s: expected size %d, got %d", tt.name, size, tt.got.Size())
+		}
+	}
+
+	if a.Count("x") != 3 || b.Count("y") != 2 {
+		t.Error("Bag algebra should not modify its operands")
+	}
+}
+
+func testBagDistinct(t *testing.T, newBag func() multiset.Bag[string]) {
+	b := bagOf(newBag, "a", "a", "b")
+	view := b.Distinct()
+
+	if view.Size() != 2 || !view.Contains("a") || view.Contains("c") {
+		t.Errorf("Unexpected distinct view %v", view.ToSlice())
+	}
+
+	b.Add("c", 2)
+	if !view.Contains("c") || view.Size() != 3 {
+		t.Error("Distinct view should reflect changes to the bag")
+	}
+
+	if view.Add("a") {
+		t.Error("Adding an existing element to the view should return false")
+	}
+	if !view.Add("d") || b.Count("d") != 1 {
+		t.Error("Adding to the view should add one occurrence")
+	}
+	if !view.Remove("a") || b.Contains("a") {
+		t.Error("Removing from the view should remove all occurrences")
+	}
+	b.Remove("c", 1)
+	if view.Size() != 3 {
+		t.Errorf("Expected 3 distinct elements, got %d", view.Size())
+	}
+	if view.TryRemove("a").IsOk() {
+		t.Error("TryRemove of a missing element should fail")
+	}
+
+	other := hashset.New[string]()
+	other.Add("b")
+	other.Add("x")
+	if got := sorted(view.Intersection(other).ToSlice()); !slices.Equal(got, []string{"b"}) {
+		t.Errorf("Unexpected intersection %v", got)
+	}
+	if got := sorted(view.Union(other).ToSlice()); !slices.Equal(got, []string{"b", "c", "d", "x"}) {
+		t.Errorf("Unexpected union %v", got)
+	}
+	if view.Contains("x") {
+		t.Error("Union should not modify the view")
+	}
+
+	view.Clear()
+	if !b.IsEmpty() || !view.IsEmpty() || view.Size() != 0 {
+		t.Error("Clearing the view should clear the bag")
+	}
+}
+
+func runBagTests(t *testing.T, newBag func() multiset.Bag[string]) {
+	t.Run("Counts", func(t *testing.T) { testBagCounts(t, newBag) })
+	t.Run("NegativeCount", func(t *testing.T) { testBagNegativeCount(t, newBag) })
+	t.Run("MostCommon", func(t *testing.T) { testBagMostCommon(t, newBag) })
+	t.Run("Algebra", func(t *testing.T) { testBagAlgebra(t, newBag) })
+	t.Run("Distinct", func(t *testing.T) { testBagDistinct(t, newBag) })
+}
+
+func TestHashBag(t *testing.T) {
+	runBagTests(t, func() multiset.Bag[string] { return multiset.New[string]() })
+}
+
+func TestOf(t *testing.T) {
+	b := multiset.Of("a", "b", "a")
+	if b.Count("a") != 2 || b.Count("b") != 1 || b.Size() != 3 {
+		t.Errorf("Unexpected counts %v", counts(b))
+	}
+}
@@ -0,0 +1,124 @@
+package persistentset
+
+import (
+	"github.com/gosuda/stdx/option"
+	"github.com/gosuda/stdx/result"
+	"github.com/gosuda/stdx/setx"
+)
+
+var _ setx.Set[int] = (*adapter[int])(nil)
+
+// adapter implements setx.Set by replacing its persistent set on every mutation.
+// It backs ReadOnly, and the sets returned by the read-only view's set operations.
+type adapter[T comparable] struct {
+	s *Set[T]
+}
+
+// Add implements setx.Set.
+func (a *adapter[T]) Add(element T) bool {
+	next := a.s.Add(element)
+	added := next != a.s
+	a.s = next
+	return added
+}
+
+// Remove implements setx.Set.
+func (a *adapter[T]) Remove(element T) bool {
+	next := a.s.Remove(element)
+	removed := next != a.s
+	a.s = next
+	return removed
+}
+
+// Clear implements setx.Set.
+func (a *adapter[T]) Clear() {
+	a.s = a.s.Clear()
+}
+
+// Contains implements setx.Set.
+func (a *adapter[T]) Contains(element T) bool {
+	return a.s.Contains(element)
+}
+
+// Size implements setx.Set.
+func (a *adapter[T]) Size() int {
+	return a.s.Size()
+}
+
+// IsEmpty implements setx.Set.
+func (a *adapter[T]) IsEmpty() bool {
+	return a.s.IsEmpty()
+}
+
+// ToSlice implements setx.Set.
+func (a *adapter[T]) ToSlice() []T {
+	return a.s.ToSlice()
+}
+
+// ForEach implements setx.Set.
+func (a *adapter[T]) ForEach(fn func(element T)) {
+	a.s.ForEach(fn)
+}
+
+// Union implements setx.Set.
+func (a *adapter[T]) Union(other setx.Set[T]) setx.Set[T] {
+	return &adapter[T]{s: a.s.Union(From(other))}
+}
+
+// Intersection implements setx.Set.
+func (a *adapter[T]) Intersection(other setx.Set[T]) setx.Set[T] {
+	return a.Filter(other.Contains)
+}
+
+// Difference implements setx.Set.
+func (a *adapter[T]) Difference(other setx.Set[T]) setx.Set[T] {
+	return a.Filter(func(element T) bool {
+		return !other.Contains(element)
+	})
+}
+
+// IsSubsetOf implements setx.Set.
+func (a *adapter[T]) IsSubsetOf(other setx.Set[T]) bool {
+	for element := range a.s.All() {
+		if !other.Contains(element) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSupersetOf implements setx.Set.
+func (a *adapter[T]) IsSupersetOf(other setx.Set[T]) bool {
+	return other.IsSubsetOf(a)
+}
+
+// Find implements setx.Set.
+func (a *adapter[T]) Find(predicate func(T) bool) option.Option[T] {
+	for element := range a.s.All() {
+		if predicate(element) {
+			return option.Some(element)
+		}
+	}
+	return option.None[T]()
+}
+
+// GetAny implements setx.Set.
+func (a *adapter[T]) GetAny() option.Option[T] {
+	for element := range a.s.All() {
+		return option.Some(element)
+	}
+	return option.None[T]()
+}
+
+// TryRemove implements setx.Set.
+func (a *adapter[T]) TryRemove(element T) result.Result[T, error] {
+	if a.Remove(element) {
+		return result.Ok[T, error](element)
+	}
+	return result.Err[T, error](setx.ErrElementNotFound)
+}
+
+// Filter implements setx.Set.
+func (a *adapter[T]) Filter(predicate func(T) bool) setx.Set[T] {
+	return &adapter[T]{s: a.s.Filter(predicate)}
+}
@@ -0,0 +1,168 @@
+// Package persistentset provides an immutable hash set with structural sharing.
+package persistentset
+
+import (
+	"iter"
+
+	"github.com/gosuda/stdx/mapx/persistent"
+	"github.com/gosuda/stdx/setx"
+)
+
+// Set is an immutable hash set backed by a persistent hash array mapped trie.
+// Add and Remove return a new version that shares most of its structure with the receiver,
+// so versions are cheap to keep and safe to share between goroutines without copying.
+//
+// The zero value is not usable; create sets with New or Of.
+type Set[T comparable] struct {
+	m *persistent.Map[T, struct{}]
+}
+
+func New[T comparable]() *Set[T] {
+	return &Set[T]{m: persistent.New[T, struct{}]()}
+}
+
+// Of creates a set containing the given elements.
+func Of[T comparable](elements ...T) *Set[T] {
+	b := persistent.NewBuilder[T, struct{}]()
+	for _, element := range elements {
+		b.Put(element, struct{}{})
+	}
+	return &Set[T]{m: b.Map()}
+}
+
+// From creates a set containing the elements of set.
+func From[T comparable](set setx.Set[T]) *Set[T] {
+	b := persistent.NewBuilder[T, struct{}]()
+	set.ForEach(func(element T) {
+		b.Put(element, struct{}{})
+	})
+	return &Set[T]{m: b.Map()}
+}
+
+// with returns s if m is its map, or a new set wrapping m.
+func (s *Set[T]) with(m *persistent.Map[T, struct{}]) *Set[T] {
+	if m == s.m {
+		return s
+	}
+	return &Set[T]{m: m}
+}
+
+// Add returns a set that also contains element. If element is already present s is returned.
+func (s *Set[T]) Add(element T) *Set[T] {
+	if s.m.ContainsKey(element) {
+		return s
+	}
+	return s.with(s.m.Set(element, struct{}{}))
+}
+
+// Remove returns a set without element. If element is not present s is returned.
+func (s *Set[T]) Remove(element T) *Set[T] {
+	return s.with(s.m.Delete(element))
+}
+
+// Clear returns an empty set.
+func (s *Set[T]) Clear() *Set[T] {
+	return s.with(s.m.Clear())
+}
+
+// Contains reports whether element is in the set.
+func (s *Set[T]) Contains(element T) bool {
+	return s.m.ContainsKey(element)
+}
+
+// Size returns the number of elements.
+func (s *Set[T]) Size() int {
+	return s.m.Size()
+}
+
+// IsEmpty reports whether the set has no elements.
+func (s *Set[T]) IsEmpty() bool {
+	return s.m.IsEmpty()
+}
+
+// All returns an iterator over the elements in unspecified order.
+func (s *Set[T]) All() iter.Seq[T] {
+	return s.m.Keys()
+}
+
+// ForEach calls fn for every element.
+func (s *Set[T]) ForEach(fn func(element T)) {
+	for element := range s.m.Keys() {
+		fn(element)
+	}
+}
+
+// ToSlice returns the elements as a slice in unspecified order.
+func (s *Set[T]) ToSlice() []T {
+	result := make([]T, 0, s.m.Size())
+	for element := range s.m.Keys() {
+		result = append(result, element)
+	}
+	return result
+}
+
+// Filter returns a set of the elements matching the predicate.
+func (s *Set[T]) Filter(predicate func(T) bool) *Set[T] {
+	return s.with(s.m.Filter(func(element T, _ struct{}) bool {
+		return predicate(element)
+	}))
+}
+
+// Union returns a set of the elements in s or other. It shares structure with the larger operand.
+func (s *Set[T]) Union(other *Set[T]) *Set[T] {
+	larger, smaller := s, other
+	if smaller.Size() > larger.Size() {
+		larger, smaller = smaller, larger
+	}
+	b := larger.m.Builder()
+	for element := range smaller.m.Keys() {
+		b.Put(element, struct{}{})
+	}
+	return larger.with(b.Map())
+}
+
+// Intersection returns a set of the elements in both s and other. It iterates the smaller operand.
+func (s *Set[T]) Intersection(other *Set[T]) *Set[T] {
+	if other.Size() < s.Size() {
+		return other.Filter(s.Contains)
+	}
+	return s.Filter(other.Contains)
+}
+
+// Difference returns a set of the elements of s that are not in other.
+func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
+	if other.Size() < s.Size() {
+		b := s.m.Builder()
+		for element := range other.m.Keys() {
+			b.Remove(element)
+		}
+		return s.with(b.Map())
+	}
+	return s.Filter(func(element T) bool {
+		return !other.Contains(element)
+	})
+}
+
+// IsSubsetOf reports whether every element of s is in other.
+func (s *Set[T]) IsSubsetOf(other *Set[T]) bool {
+	if s.Size() > other.Size() {
+		return false
+	}
+	for element := range s.m.Keys() {
+		if !other.Contains(element) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal reports whether s and other contain the same elements.
+func (s *Set[T]) Equal(other *Set[T]) bool {
+	return s.Size() == other.Size() && s.IsSubsetOf(other)
+}
+
+// ReadOnly returns a setx.Set view of s for APIs that accept the common interface.
+// Mutations panic with setx.ErrReadOnly; set operations and Filter return new mutable setx sets.
+func (s *Set[T]) ReadOnly() setx.Set[T] {
+	return setx.ReadOnly[T](&adapter[T]{s: s})
+}
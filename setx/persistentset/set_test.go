@@ -0,0 +1,164 @@
+package persistentset_test
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/gosuda/stdx/setx"
+	"github.com/gosuda/stdx/setx/hashset"
+	"github.com/gosuda/stdx/setx/persistentset"
+)
+
+func sorted(elements []int) []int {
+	slices.Sort(elements)
+	return elements
+}
+
+func TestSet_Persistence(t *testing.T) {
+	empty := persistentset.New[int]()
+	one := empty.Add(1)
+	two := one.Add(2)
+	removed := two.Remove(1)
+
+	if !empty.IsEmpty() || one.Size() != 1 || two.Size() != 2 || removed.Size() != 1 {
+		t.Error("Every version should keep its own contents")
+	}
+	if !two.Contains(1) || removed.Contains(1) || !removed.Contains(2) {
+		t.Error("Unexpected contents after Remove")
+	}
+
+	if one.Add(1) != one {
+		t.Error("Adding an existing element should return the same set")
+	}
+	if one.Remove(42) != one {
+		t.Error("Removing a missing element should return the same set")
+	}
+	if !two.Clear().IsEmpty() || two.Size() != 2 {
+		t.Error("Clear should return an empty set without changing the receiver")
+	}
+}
+
+func TestSet_Operations(t *testing.T) {
+	a := persistentset.Of(1, 2, 3, 4)
+	b := persistentset.Of(3, 4, 5)
+
+	if got := sorted(a.Union(b).ToSlice()); !slices.Equal(got, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("Unexpected union %v", got)
+	}
+	if got := sorted(a.Intersection(b).ToSlice()); !slices.Equal(got, []int{3, 4}) {
+		t.Errorf("Unexpected intersection %v", got)
+	}
+	if got := sorted(a.Difference(b).ToSlice()); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("Unexpected difference %v", got)
+	}
+	if got := sorted(b.Difference(a).ToSlice()); !slices.Equal(got, []int{5}) {
+		t.Errorf("Unexpected difference %v", got)
+	}
+	if got := sorted(a.Filter(func(x int) bool { return x%2 == 0 }).ToSlice()); !slices.Equal(got, []int{2, 4}) {
+		t.Errorf("Unexpected filter result %v", got)
+	}
+
+	if !persistentset.Of(3, 4).IsSubsetOf(a) || a.IsSubsetOf(b) {
+		t.Error("Unexpected subset relation")
+	}
+	if !a.Equal(persistentset.Of(4, 3, 2, 1)) || a.Equal(b) {
+		t.Error("Unexpected equality")
+	}
+	if a.Size() != 4 || b.Size() != 3 {
+		t.Error("Operations should not change their operands")
+	}
+
+	count := 0
+	a.ForEach(func(int) { count++ })
+	for range a.All() {
+		count++
+	}
+	if count != 8 {
+		t.Errorf("Expected 8 visits, got %d", count)
+	}
+}
+
+func TestSet_From(t *testing.T) {
+	h := hashset.New[string]()
+	h.Add("a")
+	h.Add("b")
+
+	s := persistentset.From[string](h)
+	h.Add("c")
+	if s.Size() != 2 || s.Contains("c") {
+		t.Error("From should copy the elements")
+	}
+}
+
+func TestSet_SharedAcrossGoroutines(t *testing.T) {
+	base := persistentset.New[int]()
+	for i := 0; i < 1000; i++ {
+		base = base.Add(i)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			mine := base
+			for i := 0; i < 1000; i++ {
+				mine = mine.Remove(i).Add(1000*(g+1) + i)
+			}
+			if mine.Size() != 1000 || mine.Contains(0) {
+				t.Errorf("Goroutine %d: unexpected version", g)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if base.Size() != 1000 || !base.Contains(0) || base.Contains(1000) {
+		t.Error("Shared base version should not change")
+	}
+}
+
+func TestSet_ReadOnly(t *testing.T) {
+	s := persistentset.Of(1, 2, 3)
+	view := s.ReadOnly()
+
+	if view.Size() != 3 || !view.Contains(2) || view.GetAny().IsNone() {
+		t.Error("Read-only view should expose the set's contents")
+	}
+	if err := view.TryRemove(1).UnwrapErr(); !errors.Is(err, setx.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly, got %v", err)
+	}
+	func() {
+		defer func() {
+			if r := recover(); r != setx.ErrReadOnly {
+				t.Errorf("Add should panic with ErrReadOnly, got %v", r)
+			}
+		}()
+		view.Add(4)
+	}()
+
+	other := hashset.New[int]()
+	other.Add(3)
+	other.Add(4)
+
+	union := view.Union(other)
+	if got := sorted(union.ToSlice()); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Errorf("Unexpected union %v", got)
+	}
+	if !union.Add(5) || union.Add(5) || !union.Remove(5) || union.Remove(5) {
+		t.Error("Sets returned by set operations should be mutable")
+	}
+	if got := sorted(view.Intersection(other).ToSlice()); !slices.Equal(got, []int{3}) {
+		t.Errorf("Unexpected intersection %v", got)
+	}
+	if got := sorted(view.Difference(other).ToSlice()); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("Unexpected difference %v", got)
+	}
+	if !view.IsSupersetOf(view.Filter(func(x int) bool { return x > 1 })) || view.IsSubsetOf(other) {
+		t.Error("Unexpected subset relation")
+	}
+	if s.Size() != 3 {
+		t.Error("The persistent set should be unchanged")
+	}
+}
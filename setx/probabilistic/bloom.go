@@ -0,0 +1,164 @@
+package probabilistic
+
+import (
+	"encoding/binary"
+	"math"
+	"math/bits"
+)
+
+const bloomMagic = "BLM1"
+
+// MaxHashFunctions is the largest number of hash functions a Bloom or counting Bloom filter uses.
+// Larger values passed to NewBloomWithSize are clamped, and encodings with more are rejected.
+const MaxHashFunctions = 64
+
+// Bloom is a Bloom filter: a bit array in which every item sets k bits.
+// Items cannot be removed; use CountingBloom or Cuckoo for that.
+type Bloom[T comparable] struct {
+	words  []uint64
+	m      uint64 // number of bits
+	k      uint32 // number of hash functions
+	count  uint64 // number of Add calls that changed the filter
+	hasher Hasher[T]
+}
+
+// NewBloom creates a Bloom filter sized for expectedItems with the given false positive rate.
+// A nil hasher means MapHasher. Panics if fpRate is not between 0 and 1.
+func NewBloom[T comparable](expectedItems uint, fpRate float64, hasher Hasher[T]) *Bloom[T] {
+	m, k := bloomParameters(expectedItems, fpRate)
+	return NewBloomWithSize(m, k, hasher)
+}
+
+// NewBloomWithSize creates a Bloom filter with m bits and k hash functions.
+// A nil hasher means MapHasher.
+func NewBloomWithSize[T comparable](m uint64, k uint32, hasher Hasher[T]) *Bloom[T] {
+	m, k = max(m, 1), min(max(k, 1), MaxHashFunctions)
+	return &Bloom[T]{
+		words:  make([]uint64, (m+63)/64),
+		m:      m,
+		k:      k,
+		hasher: orDefault(hasher),
+	}
+}
+
+// bloomParameters returns the optimal number of bits and hash functions.
+func bloomParameters(n uint, p float64) (m uint64, k uint32) {
+	if !(p > 0 && p < 1) {
+		panic("probabilistic: false positive rate must be between 0 and 1")
+	}
+	n = max(n, 1)
+	bitsPerItem := -math.Log(p) / (math.Ln2 * math.Ln2)
+	m = uint64(math.Ceil(float64(n) * bitsPerItem))
+	k = uint32(min(max(1, math.Round(bitsPerItem*math.Ln2)), MaxHashFunctions))
+	return m, k
+}
+
+// positions calls fn with the k bit positions of item, using double hashing.
+func positions(h uint64, k uint32, m uint64, fn func(pos uint64) bool) {
+	h2 := mix(h) | 1
+	for i := range uint64(k) {
+		if !fn((h + i*h2) % m) {
+			return
+		}
+	}
+}
+
+// Add inserts item and reports whether the filter changed,
+// which means item was definitely not present before.
+func (b *Bloom[T]) Add(item T) bool {
+	changed := false
+	positions(b.hasher(item), b.k, b.m, func(pos uint64) bool {
+		mask := uint64(1) << (pos % 64)
+		if b.words[pos/64]&mask == 0 {
+			b.words[pos/64] |= mask
+			changed = true
+		}
+		return true
+	})
+	if changed {
+		b.count++
+	}
+	return changed
+}
+
+// MightContain reports whether item may have been added.
+// A false result is certain; a true result is wrong with probability EstimatedFalsePositiveRate.
+func (b *Bloom[T]) MightContain(item T) bool {
+	found := true
+	positions(b.hasher(item), b.k, b.m, func(pos uint64) bool {
+		found = b.words[pos/64]&(1<<(pos%64)) != 0
+		return found
+	})
+	return found
+}
+
+// Count returns the number of Add calls that changed the filter, a lower bound on the number of distinct items.
+func (b *Bloom[T]) Count() uint64 {
+	return b.count
+}
+
+// EstimatedFalsePositiveRate returns the current probability that MightContain reports
+// an absent item as present, based on the fraction of bits that are set.
+func (b *Bloom[T]) EstimatedFalsePositiveRate() float64 {
+	set := 0
+	for _, w := range b.words {
+		set += bits.OnesCount64(w)
+	}
+	return math.Pow(float64(set)/float64(b.m), float64(b.k))
+}
+
+// Clear removes all items.
+func (b *Bloom[T]) Clear() {
+	clear(b.words)
+	b.count = 0
+}
+
+// Merge adds every item of other to b. Both filters must have the same size, number of hash
+// functions and hasher; ErrIncompatible is returned if the sizes differ.
+func (b *Bloom[T]) Merge(other *Bloom[T]) error {
+	if b.m != other.m || b.k != other.k {
+		return ErrIncompatible
+	}
+	for i, w := range other.words {
+		b.words[i] |= w
+	}
+	// An item added to both filters was counted by each, so the larger count is the best lower bound.
+	b.count = max(b.count, other.count)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The hasher is not encoded.
+func (b *Bloom[T]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, len(bloomMagic)+20+8*len(b.words))
+	data = append(data, bloomMagic...)
+	data = binary.LittleEndian.AppendUint64(data, b.m)
+	data = binary.LittleEndian.AppendUint32(data, b.k)
+	data = binary.LittleEndian.AppendUint64(data, b.count)
+	for _, w := range b.words {
+		data = binary.LittleEndian.AppendUint64(data, w)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. The receiver keeps its hasher,
+// which must be the one the filter was built with; a nil hasher becomes MapHasher.
+func (b *Bloom[T]) UnmarshalBinary(data []byte) error {
+	d := &decoder{data: data}
+	if !d.expect(bloomMagic) {
+		return ErrInvalidEncoding
+	}
+	m, k, count := d.uint64(), d.uint32(), d.uint64()
+	if d.bad || m == 0 || k == 0 || k > MaxHashFunctions || m > uint64(len(d.data))*8 {
+		return ErrInvalidEncoding
+	}
+	words := make([]uint64, (m+63)/64)
+	for i := range words {
+		words[i] = d.uint64()
+	}
+	if !d.done() {
+		return ErrInvalidEncoding
+	}
+	b.words, b.m, b.k, b.count = words, m, k, count
+	b.hasher = orDefault(b.hasher)
+	return nil
+}
@@ -0,0 +1,143 @@
+package probabilistic_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/gosuda/stdx/setx/probabilistic"
+)
+
+// falsePositives counts how many of n items never added are reported as present.
+func falsePositives(n int, mightContain func(string) bool) int {
+	count := 0
+	for i := 0; i < n; i++ {
+		if mightContain(fmt.Sprintf("absent-%d", i)) {
+			count++
+		}
+	}
+	return count
+}
+
+func TestBloom_Membership(t *testing.T) {
+	const n = 10000
+	f := probabilistic.NewBloom(n, 0.01, probabilistic.StringHasher)
+	for i := 0; i < n; i++ {
+		f.Add(fmt.Sprintf("item-%d", i))
+	}
+
+	for i := 0; i < n; i++ {
+		if !f.MightContain(fmt.Sprintf("item-%d", i)) {
+			t.Fatalf("False negative for item-%d", i)
+		}
+	}
+
+	rate := float64(falsePositives(n, f.MightContain)) / n
+	if rate > 0.02 {
+		t.Errorf("False positive rate %.4f exceeds twice the target", rate)
+	}
+	if est := f.EstimatedFalsePositiveRate(); est < 0.005 || est > 0.02 {
+		t.Errorf("Estimated false positive rate %.4f is far from the target", est)
+	}
+}
+
+func TestBloom_AddReportsChange(t *testing.T) {
+	f := probabilistic.NewBloom[int](100, 0.01, probabilistic.IntegerHasher[int])
+	if !f.Add(42) || f.Add(42) {
+		t.Error("Add should report a change only the first time")
+	}
+	if f.Count() != 1 {
+		t.Errorf("Expected count 1, got %d", f.Count())
+	}
+
+	f.Clear()
+	if f.MightContain(42) || f.Count() != 0 {
+		t.Error("Clear should remove all items")
+	}
+}
+
+func TestBloom_Merge(t *testing.T) {
+	a := probabilistic.NewBloom[int](1000, 0.01, nil)
+	b := probabilistic.NewBloom[int](1000, 0.01, nil)
+	a.Add(1)
+	b.Add(2)
+
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if !a.MightContain(1) || !a.MightContain(2) {
+		t.Error("Merged filter should contain items of both filters")
+	}
+
+	c := probabilistic.NewBloom[int](1000, 0.01, nil)
+	d := probabilistic.NewBloom[int](1000, 0.01, nil)
+	for i := range 10 {
+		c.Add(i)
+		d.Add(i)
+	}
+	if err := c.Merge(d); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if c.Count() > 10 {
+		t.Errorf("Merging identical filters should not double-count, got %d", c.Count())
+	}
+
+	other := probabilistic.NewBloom[int](50, 0.01, nil)
+	if err := a.Merge(other); !errors.Is(err, probabilistic.ErrIncompatible) {
+		t.Errorf("Expected ErrIncompatible, got %v", err)
+	}
+}
+
+func TestBloom_Binary(t *testing.T) {
+	f := probabilistic.NewBloom(500, 0.01, probabilistic.StringHasher)
+	for i := 0; i < 500; i++ {
+		f.Add(fmt.Sprintf("item-%d", i))
+	}
+	data, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+
+	restored := probabilistic.NewBloom(1, 0.5, probabilistic.StringHasher)
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	for i := 0; i < 500; i++ {
+		if !restored.MightContain(fmt.Sprintf("item-%d", i)) {
+			t.Fatalf("Restored filter lost item-%d", i)
+		}
+	}
+	if restored.Count() != f.Count() {
+		t.Errorf("Expected count %d, got %d", f.Count(), restored.Count())
+	}
+
+	for _, bad := range [][]byte{nil, []byte("XXXX"), data[:len(data)-1]} {
+		if err := restored.UnmarshalBinary(bad); !errors.Is(err, probabilistic.ErrInvalidEncoding) {
+			t.Errorf("Expected ErrInvalidEncoding, got %v", err)
+		}
+	}
+}
+
+func TestBloom_HashFunctionLimit(t *testing.T) {
+	if data, _ := probabilistic.NewBloomWithSize[int](1024, 1000, nil).MarshalBinary(); binary.LittleEndian.Uint32(data[12:]) != probabilistic.MaxHashFunctions {
+		t.Error("NewBloomWithSize should clamp k to MaxHashFunctions")
+	}
+
+	data, _ := probabilistic.NewBloom[int](100, 0.01, nil).MarshalBinary()
+	binary.LittleEndian.PutUint32(data[12:], math.MaxUint32)
+	restored := probabilistic.NewBloom[int](1, 0.5, nil)
+	if err := restored.UnmarshalBinary(data); !errors.Is(err, probabilistic.ErrInvalidEncoding) {
+		t.Errorf("Expected ErrInvalidEncoding for a huge k, got %v", err)
+	}
+}
+
+func TestBloom_InvalidRate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for a false positive rate of 1")
+		}
+	}()
+	probabilistic.NewBloom[int](10, 1, nil)
+}
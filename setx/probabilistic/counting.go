@@ -0,0 +1,138 @@
+package probabilistic
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+const countingMagic = "CBF1"
+
+// CountingBloom is a Bloom filter with an 8-bit counter instead of a bit per position,
+// which makes removal possible. A counter that reaches 255 sticks there, so items hashing
+// to it can no longer be removed completely; this only matters for heavily overfilled filters.
+type CountingBloom[T comparable] struct {
+	counters []uint8
+	k        uint32
+	count    uint64 // number of items added and not removed
+	hasher   Hasher[T]
+}
+
+// NewCountingBloom creates a counting Bloom filter sized for expectedItems with the given false positive rate.
+// A nil hasher means MapHasher. Panics if fpRate is not between 0 and 1.
+func NewCountingBloom[T comparable](expectedItems uint, fpRate float64, hasher Hasher[T]) *CountingBloom[T] {
+	m, k := bloomParameters(expectedItems, fpRate)
+	return &CountingBloom[T]{
+		counters: make([]uint8, m),
+		k:        k,
+		hasher:   orDefault(hasher),
+	}
+}
+
+func (c *CountingBloom[T]) positions(item T, fn func(pos uint64) bool) {
+	positions(c.hasher(item), c.k, uint64(len(c.counters)), fn)
+}
+
+// Add inserts item. Adding the same item twice requires removing it twice.
+func (c *CountingBloom[T]) Add(item T) {
+	c.positions(item, func(pos uint64) bool {
+		if c.counters[pos] < math.MaxUint8 {
+			c.counters[pos]++
+		}
+		return true
+	})
+	c.count++
+}
+
+// MightContain reports whether item may have been added.
+// A false result is certain; a true result is wrong with probability EstimatedFalsePositiveRate.
+func (c *CountingBloom[T]) MightContain(item T) bool {
+	found := true
+	c.positions(item, func(pos uint64) bool {
+		found = c.counters[pos] != 0
+		return found
+	})
+	return found
+}
+
+// Remove removes one occurrence of item and reports whether it might have been present.
+// Removing an item that was never added can cause false negatives for other items,
+// unless MightContain reported it as absent, in which case nothing is changed.
+func (c *CountingBloom[T]) Remove(item T) bool {
+	if !c.MightContain(item) {
+		return false
+	}
+	c.positions(item, func(pos uint64) bool {
+		if c.counters[pos] < math.MaxUint8 {
+			c.counters[pos]--
+		}
+		return true
+	})
+	c.count--
+	return true
+}
+
+// Count returns the number of items added and not removed.
+func (c *CountingBloom[T]) Count() uint64 {
+	return c.count
+}
+
+// EstimatedFalsePositiveRate returns the current probability that MightContain reports
+// an absent item as present, based on the fraction of non-zero counters.
+func (c *CountingBloom[T]) EstimatedFalsePositiveRate() float64 {
+	set := 0
+	for _, counter := range c.counters {
+		if counter != 0 {
+			set++
+		}
+	}
+	return math.Pow(float64(set)/float64(len(c.counters)), float64(c.k))
+}
+
+// Clear removes all items.
+func (c *CountingBloom[T]) Clear() {
+	clear(c.counters)
+	c.count = 0
+}
+
+// Merge adds every item of other to c. Both filters must have the same size, number of hash
+// functions and hasher; ErrIncompatible is returned if the sizes differ.
+//
+// Merge sums occurrences rather than taking a set union: an item added to both filters is
+// counted twice, both by Count and by its counters, and needs two calls to Remove to disappear.
+func (c *CountingBloom[T]) Merge(other *CountingBloom[T]) error {
+	if len(c.counters) != len(other.counters) || c.k != other.k {
+		return ErrIncompatible
+	}
+	for i, counter := range other.counters {
+		c.counters[i] = uint8(min(int(c.counters[i])+int(counter), math.MaxUint8))
+	}
+	c.count += other.count
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The hasher is not encoded.
+func (c *CountingBloom[T]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, len(countingMagic)+20+len(c.counters))
+	data = append(data, countingMagic...)
+	data = binary.LittleEndian.AppendUint64(data, uint64(len(c.counters)))
+	data = binary.LittleEndian.AppendUint32(data, c.k)
+	data = binary.LittleEndian.AppendUint64(data, c.count)
+	return append(data, c.counters...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. The receiver keeps its hasher,
+// which must be the one the filter was built with; a nil hasher becomes MapHasher.
+func (c *CountingBloom[T]) UnmarshalBinary(data []byte) error {
+	d := &decoder{data: data}
+	if !d.expect(countingMagic) {
+		return ErrInvalidEncoding
+	}
+	m, k, count := d.uint64(), d.uint32(), d.uint64()
+	if d.bad || m == 0 || k == 0 || k > MaxHashFunctions || m != uint64(len(d.data)) {
+		return ErrInvalidEncoding
+	}
+	c.counters = append([]uint8(nil), d.bytes(int(m))...)
+	c.k, c.count = k, count
+	c.hasher = orDefault(c.hasher)
+	return nil
+}
@@ -0,0 +1,114 @@
+package probabilistic_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/gosuda/stdx/setx/probabilistic"
+)
+
+func TestCountingBloom_AddRemove(t *testing.T) {
+	const n = 5000
+	f := probabilistic.NewCountingBloom(n, 0.01, probabilistic.StringHasher)
+	for i := 0; i < n; i++ {
+		f.Add(fmt.Sprintf("item-%d", i))
+	}
+	for i := 0; i < n; i += 2 {
+		if !f.Remove(fmt.Sprintf("item-%d", i)) {
+			t.Fatalf("Failed to remove item-%d", i)
+		}
+	}
+
+	if f.Count() != n/2 {
+		t.Errorf("Expected count %d, got %d", n/2, f.Count())
+	}
+	for i := 1; i < n; i += 2 {
+		if !f.MightContain(fmt.Sprintf("item-%d", i)) {
+			t.Fatalf("False negative for item-%d after removing other items", i)
+		}
+	}
+	removed := 0
+	for i := 0; i < n; i += 2 {
+		if !f.MightContain(fmt.Sprintf("item-%d", i)) {
+			removed++
+		}
+	}
+	if removed < n/2*9/10 {
+		t.Errorf("Only %d of %d removed items are reported absent", removed, n/2)
+	}
+	if rate := float64(falsePositives(n, f.MightContain)) / n; rate > 0.02 {
+		t.Errorf("False positive rate %.4f exceeds twice the target", rate)
+	}
+}
+
+func TestCountingBloom_Duplicates(t *testing.T) {
+	f := probabilistic.NewCountingBloom[int](100, 0.01, probabilistic.IntegerHasher[int])
+	f.Add(7)
+	f.Add(7)
+
+	if !f.Remove(7) || !f.MightContain(7) {
+		t.Error("An item added twice should survive one removal")
+	}
+	if !f.Remove(7) || f.MightContain(7) {
+		t.Error("An item added twice should be gone after two removals")
+	}
+	if f.Remove(7) {
+		t.Error("Removing an absent item should report false")
+	}
+}
+
+func TestCountingBloom_MergeBinary(t *testing.T) {
+	a := probabilistic.NewCountingBloom(100, 0.01, probabilistic.StringHasher)
+	b := probabilistic.NewCountingBloom(100, 0.01, probabilistic.StringHasher)
+	a.Add("a")
+	b.Add("b")
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if err := a.Merge(probabilistic.NewCountingBloom(10, 0.01, probabilistic.StringHasher)); !errors.Is(err, probabilistic.ErrIncompatible) {
+		t.Errorf("Expected ErrIncompatible, got %v", err)
+	}
+
+	data, _ := a.MarshalBinary()
+	restored := probabilistic.NewCountingBloom(1, 0.5, probabilistic.StringHasher)
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if !restored.MightContain("a") || !restored.MightContain("b") || restored.Count() != 2 {
+		t.Error("Restored filter should contain both merged items")
+	}
+	if !restored.Remove("a") || restored.MightContain("a") {
+		t.Error("Restored filter should support removal")
+	}
+	if err := restored.UnmarshalBinary(data[:10]); !errors.Is(err, probabilistic.ErrInvalidEncoding) {
+		t.Errorf("Expected ErrInvalidEncoding, got %v", err)
+	}
+	binary.LittleEndian.PutUint32(data[12:], math.MaxUint32)
+	if err := restored.UnmarshalBinary(data); !errors.Is(err, probabilistic.ErrInvalidEncoding) {
+		t.Errorf("Expected ErrInvalidEncoding for a huge k, got %v", err)
+	}
+}
+
+func TestCountingBloom_MergeShared(t *testing.T) {
+	a := probabilistic.NewCountingBloom(100, 0.01, probabilistic.StringHasher)
+	b := probabilistic.NewCountingBloom(100, 0.01, probabilistic.StringHasher)
+	a.Add("shared")
+	b.Add("shared")
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if a.Count() != 2 {
+		t.Errorf("Merge should sum occurrences, got count %d", a.Count())
+	}
+	a.Remove("shared")
+	if !a.MightContain("shared") || a.Count() != 1 {
+		t.Error("A shared item should need one Remove per merged occurrence")
+	}
+	a.Remove("shared")
+	if a.MightContain("shared") || a.Count() != 0 {
+		t.Error("A shared item should be gone after both occurrences are removed")
+	}
+}
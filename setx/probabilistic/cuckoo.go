@@ -0,0 +1,231 @@
+package probabilistic
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/bits"
+)
+
+const (
+	cuckooMagic      = "CKO1"
+	cuckooBucketSize = 4
+	cuckooMaxKicks   = 500
+	cuckooLoadFactor = 0.95
+)
+
+// ErrFull is returned by Cuckoo.Merge when the filter has no room for the other filter's items.
+var ErrFull = errors.New("probabilistic: filter is full")
+
+// Cuckoo is a cuckoo filter storing a 16-bit fingerprint of every item in one of two candidate buckets.
+// It supports removal and has a lower false positive rate than a Bloom filter of the same size
+// for rates below about 3%, but Add fails once the filter is close to full.
+//
+// Like CountingBloom it counts duplicates: adding an item twice requires removing it twice.
+type Cuckoo[T comparable] struct {
+	entries []uint16 // cuckooBucketSize fingerprints per bucket, 0 means empty
+	mask    uint64   // number of buckets - 1
+	count   uint64
+	rng     uint64
+	hasher  Hasher[T]
+}
+
+// NewCuckoo creates a cuckoo filter able to hold at least capacity items.
+// A nil hasher means MapHasher.
+func NewCuckoo[T comparable](capacity uint, hasher Hasher[T]) *Cuckoo[T] {
+	buckets := uint64(math.Ceil(float64(max(capacity, 1)) / cuckooBucketSize / cuckooLoadFactor))
+	buckets = 1 << bits.Len64(buckets-1)
+	return &Cuckoo[T]{
+		entries: make([]uint16, buckets*cuckooBucketSize),
+		mask:    buckets - 1,
+		rng:     1,
+		hasher:  orDefault(hasher),
+	}
+}
+
+// locate returns the fingerprint and primary bucket of item.
+func (c *Cuckoo[T]) locate(item T) (fp uint16, i uint64) {
+	h := c.hasher(item)
+	fp = uint16(h >> 48)
+	if fp == 0 {
+		fp = 1
+	}
+	return fp, h & c.mask
+}
+
+// alt returns the other candidate bucket of a fingerprint stored in bucket i.
+func (c *Cuckoo[T]) alt(i uint64, fp uint16) uint64 {
+	return (i ^ mix(uint64(fp))) & c.mask
+}
+
+// bucket returns the fingerprints of bucket i.
+func (c *Cuckoo[T]) bucket(i uint64) []uint16 {
+	return c.entries[i*cuckooBucketSize : (i+1)*cuckooBucketSize]
+}
+
+// place stores fp in a free slot of bucket i and reports whether there was one.
+func (c *Cuckoo[T]) place(i uint64, fp uint16) bool {
+	b := c.bucket(i)
+	for j, entry := range b {
+		if entry == 0 {
+			b[j] = fp
+			return true
+		}
+	}
+	return false
+}
+
+// random returns the next value of a xorshift generator used to pick eviction victims.
+func (c *Cuckoo[T]) random() uint64 {
+	c.rng ^= c.rng << 13
+	c.rng ^= c.rng >> 7
+	c.rng ^= c.rng << 17
+	return c.rng
+}
+
+// insert stores fp in bucket i or its alternate, evicting other fingerprints if needed.
+// If no place is found the filter is restored to its previous state and false is returned.
+func (c *Cuckoo[T]) insert(i uint64, fp uint16) bool {
+	if c.place(i, fp) || c.place(c.alt(i, fp), fp) {
+		c.count++
+		return true
+	}
+	if c.random()&1 == 1 {
+		i = c.alt(i, fp)
+	}
+	path := make([]uint64, 0, cuckooMaxKicks)
+	for range cuckooMaxKicks {
+		slot := i*cuckooBucketSize + c.random()%cuckooBucketSize
+		path = append(path, slot)
+		fp, c.entries[slot] = c.entries[slot], fp
+		i = c.alt(i, fp)
+		if c.place(i, fp) {
+			c.count++
+			return true
+		}
+	}
+	for j := len(path) - 1; j >= 0; j-- {
+		fp, c.entries[path[j]] = c.entries[path[j]], fp
+	}
+	return false
+}
+
+// Add inserts item and reports whether there was room for it. A false result means the filter is full
+// and was left unchanged.
+func (c *Cuckoo[T]) Add(item T) bool {
+	fp, i := c.locate(item)
+	return c.insert(i, fp)
+}
+
+// MightContain reports whether item may have been added.
+// A false result is certain; a true result is wrong with probability EstimatedFalsePositiveRate.
+func (c *Cuckoo[T]) MightContain(item T) bool {
+	fp, i := c.locate(item)
+	for _, b := range [][]uint16{c.bucket(i), c.bucket(c.alt(i, fp))} {
+		for _, entry := range b {
+			if entry == fp {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Remove removes one occurrence of item and reports whether it might have been present.
+// Removing an item that was never added can remove another item sharing its fingerprint.
+func (c *Cuckoo[T]) Remove(item T) bool {
+	fp, i := c.locate(item)
+	for _, b := range [][]uint16{c.bucket(i), c.bucket(c.alt(i, fp))} {
+		for j, entry := range b {
+			if entry == fp {
+				b[j] = 0
+				c.count--
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Count returns the number of items added and not removed.
+func (c *Cuckoo[T]) Count() uint64 {
+	return c.count
+}
+
+// LoadFactor returns the fraction of occupied slots.
+func (c *Cuckoo[T]) LoadFactor() float64 {
+	return float64(c.count) / float64(len(c.entries))
+}
+
+// EstimatedFalsePositiveRate returns the current probability that MightContain reports
+// an absent item as present: each lookup compares against the occupied slots of two buckets.
+func (c *Cuckoo[T]) EstimatedFalsePositiveRate() float64 {
+	compared := 2 * cuckooBucketSize * c.LoadFactor()
+	return 1 - math.Pow(1-1.0/math.MaxUint16, compared)
+}
+
+// Clear removes all items.
+func (c *Cuckoo[T]) Clear() {
+	clear(c.entries)
+	c.count = 0
+}
+
+// Merge adds every item of other to c. Both filters must have the same number of buckets and the same hasher;
+// ErrIncompatible is returned otherwise. ErrFull is returned if c runs out of room, in which case
+// some of the items of other have been added.
+func (c *Cuckoo[T]) Merge(other *Cuckoo[T]) error {
+	if c.mask != other.mask {
+		return ErrIncompatible
+	}
+	for i := range c.mask + 1 {
+		for _, fp := range other.bucket(i) {
+			if fp != 0 && !c.insert(i, fp) {
+				return ErrFull
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The hasher is not encoded.
+func (c *Cuckoo[T]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, len(cuckooMagic)+16+2*len(c.entries))
+	data = append(data, cuckooMagic...)
+	data = binary.LittleEndian.AppendUint64(data, c.mask+1)
+	data = binary.LittleEndian.AppendUint64(data, c.count)
+	for _, entry := range c.entries {
+		data = binary.LittleEndian.AppendUint16(data, entry)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. The receiver keeps its hasher,
+// which must be the one the filter was built with; a nil hasher becomes MapHasher.
+func (c *Cuckoo[T]) UnmarshalBinary(data []byte) error {
+	d := &decoder{data: data}
+	if !d.expect(cuckooMagic) {
+		return ErrInvalidEncoding
+	}
+	buckets, count := d.uint64(), d.uint64()
+	if d.bad || buckets == 0 || buckets&(buckets-1) != 0 || buckets > uint64(len(d.data)) ||
+		buckets*cuckooBucketSize*2 != uint64(len(d.data)) {
+		return ErrInvalidEncoding
+	}
+	entries := make([]uint16, buckets*cuckooBucketSize)
+	occupied := uint64(0)
+	for i := range entries {
+		entries[i] = binary.LittleEndian.Uint16(d.bytes(2))
+		if entries[i] != 0 {
+			occupied++
+		}
+	}
+	if occupied != count {
+		return ErrInvalidEncoding
+	}
+	c.entries, c.mask, c.count = entries, buckets-1, count
+	if c.rng == 0 {
+		c.rng = 1
+	}
+	c.hasher = orDefault(c.hasher)
+	return nil
+}
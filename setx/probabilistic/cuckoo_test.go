@@ -0,0 +1,103 @@
+package probabilistic_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gosuda/stdx/setx/probabilistic"
+)
+
+func TestCuckoo_AddRemove(t *testing.T) {
+	const n = 10000
+	f := probabilistic.NewCuckoo(n, probabilistic.StringHasher)
+	for i := 0; i < n; i++ {
+		if !f.Add(fmt.Sprintf("item-%d", i)) {
+			t.Fatalf("Filter sized for %d items is full after %d", n, i)
+		}
+	}
+	for i := 0; i < n; i++ {
+		if !f.MightContain(fmt.Sprintf("item-%d", i)) {
+			t.Fatalf("False negative for item-%d", i)
+		}
+	}
+	if rate := float64(falsePositives(n, f.MightContain)) / n; rate > 0.001 {
+		t.Errorf("False positive rate %.4f is unexpectedly high", rate)
+	}
+
+	for i := 0; i < n; i += 2 {
+		if !f.Remove(fmt.Sprintf("item-%d", i)) {
+			t.Fatalf("Failed to remove item-%d", i)
+		}
+	}
+	if f.Count() != n/2 {
+		t.Errorf("Expected count %d, got %d", n/2, f.Count())
+	}
+	for i := 1; i < n; i += 2 {
+		if !f.MightContain(fmt.Sprintf("item-%d", i)) {
+			t.Fatalf("False negative for item-%d after removing other items", i)
+		}
+	}
+	if est := f.EstimatedFalsePositiveRate(); est <= 0 || est > 0.001 {
+		t.Errorf("Unexpected estimated false positive rate %.6f", est)
+	}
+}
+
+func TestCuckoo_Full(t *testing.T) {
+	f := probabilistic.NewCuckoo[int](8, probabilistic.IntegerHasher[int])
+	added := 0
+	for i := 0; f.Add(i); i++ {
+		added++
+	}
+
+	// A failed Add leaves every stored item in place.
+	for i := 0; i < added; i++ {
+		if !f.MightContain(i) {
+			t.Fatalf("False negative for %d after the filter filled up", i)
+		}
+	}
+	if f.Count() != uint64(added) || f.LoadFactor() > 1 {
+		t.Errorf("Unexpected count %d or load factor %.2f", f.Count(), f.LoadFactor())
+	}
+}
+
+func TestCuckoo_MergeBinary(t *testing.T) {
+	a := probabilistic.NewCuckoo(100, probabilistic.StringHasher)
+	b := probabilistic.NewCuckoo(100, probabilistic.StringHasher)
+	for i := 0; i < 50; i++ {
+		a.Add(fmt.Sprintf("a-%d", i))
+		b.Add(fmt.Sprintf("b-%d", i))
+	}
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if err := a.Merge(probabilistic.NewCuckoo(1000, probabilistic.StringHasher)); !errors.Is(err, probabilistic.ErrIncompatible) {
+		t.Errorf("Expected ErrIncompatible, got %v", err)
+	}
+
+	data, _ := a.MarshalBinary()
+	restored := probabilistic.NewCuckoo(1, probabilistic.StringHasher)
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	for i := 0; i < 50; i++ {
+		if !restored.MightContain(fmt.Sprintf("a-%d", i)) || !restored.MightContain(fmt.Sprintf("b-%d", i)) {
+			t.Fatalf("Restored filter lost item %d", i)
+		}
+	}
+	if restored.Count() != 100 {
+		t.Errorf("Expected count 100, got %d", restored.Count())
+	}
+	if err := restored.UnmarshalBinary(data[:len(data)-2]); !errors.Is(err, probabilistic.ErrInvalidEncoding) {
+		t.Errorf("Expected ErrInvalidEncoding, got %v", err)
+	}
+
+	full := probabilistic.NewCuckoo[int](8, probabilistic.IntegerHasher[int])
+	for i := 0; full.Add(i); i++ {
+	}
+	other := probabilistic.NewCuckoo[int](8, probabilistic.IntegerHasher[int])
+	other.Add(-1)
+	if err := full.Merge(other); !errors.Is(err, probabilistic.ErrFull) {
+		t.Errorf("Expected ErrFull, got %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+// Package probabilistic provides space-efficient approximate membership filters
+// and the HyperLogLog cardinality sketch.
+//
+// A filter answers MightContain with no false negatives and a tunable rate of false positives,
+// which makes it a cheap pre-check in front of an expensive exact lookup.
+//
+// Filters and sketches hash items with a pluggable Hasher. Those that are merged or serialized
+// and restored must use the same hasher; MapHasher is only stable within a process, so use
+// StringHasher, IntegerHasher or a custom stable hasher for anything that is persisted.
+package probabilistic
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+	"hash/maphash"
+)
+
+var (
+	// ErrIncompatible is returned when merging filters created with different parameters.
+	ErrIncompatible = errors.New("probabilistic: filters are incompatible")
+
+	// ErrInvalidEncoding is returned by UnmarshalBinary when the data is not a valid encoding of the filter.
+	ErrInvalidEncoding = errors.New("probabilistic: invalid binary encoding")
+)
+
+// Hasher returns a 64-bit hash of an item. Its bits should be uniformly distributed.
+type Hasher[T comparable] func(item T) uint64
+
+// seed is shared by every MapHasher so that filters within a process can be merged.
+var seed = maphash.MakeSeed()
+
+// MapHasher returns a hasher for any comparable type based on hash/maphash.
+// The hash differs between processes.
+func MapHasher[T comparable]() Hasher[T] {
+	return func(item T) uint64 {
+		return maphash.Comparable(seed, item)
+	}
+}
+
+// StringHasher hashes strings with 64-bit FNV-1a. It is stable across processes.
+func StringHasher(item string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(item))
+	return mix(h.Sum64())
+}
+
+// IntegerHasher hashes integers with the SplitMix64 finalizer. It is stable across processes.
+func IntegerHasher[T ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr](item T) uint64 {
+	return mix(uint64(item))
+}
+
+// mix is the SplitMix64 finalizer. It spreads the entropy of x over all 64 bits.
+func mix(x uint64) uint64 {
+	x += 0x9E3779B97F4A7C15
+	x = (x ^ (x >> 30)) * 0xBF58476D1CE4E5B9
+	x = (x ^ (x >> 27)) * 0x94D049BB133111EB
+	return x ^ (x >> 31)
+}
+
+// orDefault returns hasher, or MapHasher if it is nil.
+func orDefault[T comparable](hasher Hasher[T]) Hasher[T] {
+	if hasher == nil {
+		return MapHasher[T]()
+	}
+	return hasher
+}
+
+// decoder consumes little-endian values from a byte slice, remembering whether it ran out of data.
+type decoder struct {
+	data []byte
+	bad  bool
+}
+
+func (d *decoder) bytes(n int) []byte {
+	if d.bad || n < 0 || n > len(d.data) {
+		d.bad = true
+		return nil
+	}
+	b := d.data[:n]
+	d.data = d.data[n:]
+	return b
+}
+
+func (d *decoder) uint32() uint32 {
+	if b := d.bytes(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (d *decoder) uint64() uint64 {
+	if b := d.bytes(8); b != nil {
+		return binary.LittleEndian.Uint64(b)
+	}
+	return 0
+}
+
+// expect consumes magic and reports whether the data started with it.
+func (d *decoder) expect(magic string) bool {
+	return string(d.bytes(len(magic))) == magic && !d.bad
+}
+
+// done reports whether the whole input was consumed without running out of data.
+func (d *decoder) done() bool {
+	return !d.bad && len(d.data) == 0
+}
@@ -0,0 +1,123 @@
+package probabilistic_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/gosuda/stdx/setx/probabilistic"
+)
+
+// relativeError returns |estimate - actual| / actual.
+func relativeError(estimate uint64, actual int) float64 {
+	return math.Abs(float64(estimate)-float64(actual)) / float64(actual)
+}
+
+func TestHyperLogLog_Accuracy(t *testing.T) {
+	for _, n := range []int{10, 1000, 5000, 100000, 1000000} {
+		h := probabilistic.NewHyperLogLog[int](14, probabilistic.IntegerHasher[int])
+		for i := 0; i < n; i++ {
+			h.Add(i)
+			h.Add(i) // duplicates must not be counted
+		}
+		// Standard error at precision 14 is 0.8%; allow four standard errors.
+		if err := relativeError(h.Count(), n); err > 0.033 {
+			t.Errorf("n=%d: estimate %d has relative error %.4f", n, h.Count(), err)
+		}
+	}
+}
+
+func TestHyperLogLog_SparseIsNearlyExact(t *testing.T) {
+	h := probabilistic.NewHyperLogLog(12, probabilistic.StringHasher)
+	if h.Count() != 0 {
+		t.Errorf("Empty sketch should count 0, got %d", h.Count())
+	}
+	for i := 0; i < 500; i++ {
+		h.Add(fmt.Sprintf("user-%d", i))
+	}
+	if got := h.Count(); got < 498 || got > 502 {
+		t.Errorf("Sparse estimate should be nearly exact, got %d for 500", got)
+	}
+
+	h.Clear()
+	if h.Count() != 0 {
+		t.Errorf("Cleared sketch should count 0, got %d", h.Count())
+	}
+}
+
+func TestHyperLogLog_Merge(t *testing.T) {
+	for _, n := range []int{300, 50000} {
+		a := probabilistic.NewHyperLogLog[int](14, probabilistic.IntegerHasher[int])
+		b := probabilistic.NewHyperLogLog[int](14, probabilistic.IntegerHasher[int])
+		for i := 0; i < n; i++ {
+			a.Add(i)
+			b.Add(i + n/2)
+		}
+		if err := a.Merge(b); err != nil {
+			t.Fatalf("Merge failed: %v", err)
+		}
+		if err := relativeError(a.Count(), n+n/2); err > 0.033 {
+			t.Errorf("n=%d: merged estimate %d has relative error %.4f", n, a.Count(), err)
+		}
+	}
+
+	sparse := probabilistic.NewHyperLogLog[int](14, probabilistic.IntegerHasher[int])
+	dense := probabilistic.NewHyperLogLog[int](14, probabilistic.IntegerHasher[int])
+	for i := 0; i < 100000; i++ {
+		dense.Add(i)
+	}
+	for i := 100000; i < 100100; i++ {
+		sparse.Add(i)
+	}
+	if err := sparse.Merge(dense); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if err := relativeError(sparse.Count(), 100100); err > 0.033 {
+		t.Errorf("Sparse into dense merge estimate %d is off", sparse.Count())
+	}
+
+	if err := sparse.Merge(probabilistic.NewHyperLogLog[int](10, nil)); !errors.Is(err, probabilistic.ErrIncompatible) {
+		t.Errorf("Expected ErrIncompatible, got %v", err)
+	}
+}
+
+func TestHyperLogLog_Binary(t *testing.T) {
+	for _, n := range []int{100, 100000} {
+		h := probabilistic.NewHyperLogLog(12, probabilistic.StringHasher)
+		for i := 0; i < n; i++ {
+			h.Add(fmt.Sprintf("user-%d", i))
+		}
+		data, err := h.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary failed: %v", err)
+		}
+
+		restored := probabilistic.NewHyperLogLog(4, probabilistic.StringHasher)
+		if err := restored.UnmarshalBinary(data); err != nil {
+			t.Fatalf("UnmarshalBinary failed: %v", err)
+		}
+		if restored.Count() != h.Count() || restored.Precision() != 12 {
+			t.Errorf("n=%d: expected %d, got %d", n, h.Count(), restored.Count())
+		}
+
+		// Restored sketches keep counting.
+		restored.Add("another")
+		if restored.Count() < h.Count() {
+			t.Error("Adding to a restored sketch should not lower its count")
+		}
+
+		if err := restored.UnmarshalBinary(data[:len(data)-1]); !errors.Is(err, probabilistic.ErrInvalidEncoding) {
+			t.Errorf("Expected ErrInvalidEncoding, got %v", err)
+		}
+	}
+}
+
+func TestHyperLogLog_InvalidPrecision(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for precision 19")
+		}
+	}()
+	probabilistic.NewHyperLogLog[int](19, nil)
+}
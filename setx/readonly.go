@@ -0,0 +1,111 @@
+package setx
+
+import (
+	"errors"
+
+	"github.com/gosuda/stdx/option"
+	"github.com/gosuda/stdx/result"
+)
+
+// ErrReadOnly is returned or panicked with when a read-only set is mutated.
+var ErrReadOnly = errors.New("set is read-only")
+
+// ReadOnly returns a view of set that panics with ErrReadOnly on Add, Remove and Clear
+// and returns Err(ErrReadOnly) from TryRemove. Reads go to set, so later changes made
+// through set itself remain visible. Set operations and Filter return new sets from set's implementation.
+func ReadOnly[T comparable](set Set[T]) Set[T] {
+	if r, ok := set.(readOnly[T]); ok {
+		return r
+	}
+	return readOnly[T]{set: set}
+}
+
+// readOnly is the view returned by ReadOnly.
+type readOnly[T comparable] struct {
+	set Set[T]
+}
+
+// Add implements Set. It always panics.
+func (r readOnly[T]) Add(element T) bool {
+	panic(ErrReadOnly)
+}
+
+// Remove implements Set. It always panics.
+func (r readOnly[T]) Remove(element T) bool {
+	panic(ErrReadOnly)
+}
+
+// Clear implements Set. It always panics.
+func (r readOnly[T]) Clear() {
+	panic(ErrReadOnly)
+}
+
+// TryRemove implements Set. It always returns Err(ErrReadOnly).
+func (r readOnly[T]) TryRemove(element T) result.Result[T, error] {
+	return result.Err[T, error](ErrReadOnly)
+}
+
+// Contains implements Set.
+func (r readOnly[T]) Contains(element T) bool {
+	return r.set.Contains(element)
+}
+
+// Size implements Set.
+func (r readOnly[T]) Size() int {
+	return r.set.Size()
+}
+
+// IsEmpty implements Set.
+func (r readOnly[T]) IsEmpty() bool {
+	return r.set.IsEmpty()
+}
+
+// ToSlice implements Set.
+func (r readOnly[T]) ToSlice() []T {
+	return r.set.ToSlice()
+}
+
+// ForEach implements Set.
+func (r readOnly[T]) ForEach(fn func(element T)) {
+	r.set.ForEach(fn)
+}
+
+// Union implements Set.
+func (r readOnly[T]) Union(other Set[T]) Set[T] {
+	return r.set.Union(other)
+}
+
+// Intersection implements Set.
+func (r readOnly[T]) Intersection(other Set[T]) Set[T] {
+	return r.set.Intersection(other)
+}
+
+// Difference implements Set.
+func (r readOnly[T]) Difference(other Set[T]) Set[T] {
+	return r.set.Difference(other)
+}
+
+// IsSubsetOf implements Set.
+func (r readOnly[T]) IsSubsetOf(other Set[T]) bool {
+	return r.set.IsSubsetOf(other)
+}
+
+// IsSupersetOf implements Set.
+func (r readOnly[T]) IsSupersetOf(other Set[T]) bool {
+	return r.set.IsSupersetOf(other)
+}
+
+// Find implements Set.
+func (r readOnly[T]) Find(predicate func(T) bool) option.Option[T] {
+	return r.set.Find(predicate)
+}
+
+// GetAny implements Set.
+func (r readOnly[T]) GetAny() option.Option[T] {
+	return r.set.GetAny()
+}
+
+// Filter implements Set.
+func (r readOnly[T]) Filter(predicate func(T) bool) Set[T] {
+	return r.set.Filter(predicate)
+}
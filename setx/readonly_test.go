@@ -0,0 +1,53 @@
+package setx_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gosuda/stdx/setx"
+	"github.com/gosuda/stdx/setx/hashset"
+)
+
+func TestReadOnly(t *testing.T) {
+	inner := hashset.New[int]()
+	inner.Add(1)
+	inner.Add(2)
+	view := setx.ReadOnly[int](inner)
+
+	if view.Size() != 2 || !view.Contains(1) || view.IsEmpty() {
+		t.Error("Read-only view should expose the set's contents")
+	}
+	inner.Add(3)
+	if !view.Contains(3) {
+		t.Error("Read-only view should observe changes made through the original set")
+	}
+
+	if err := view.TryRemove(1).UnwrapErr(); !errors.Is(err, setx.ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly, got %v", err)
+	}
+	for name, mutate := range map[string]func(){
+		"Add":    func() { view.Add(4) },
+		"Remove": func() { view.Remove(1) },
+		"Clear":  func() { view.Clear() },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r != setx.ErrReadOnly {
+					t.Errorf("%s should panic with ErrReadOnly, got %v", name, r)
+				}
+			}()
+			mutate()
+		}()
+	}
+	if inner.Size() != 3 {
+		t.Error("Failed mutations should not reach the original set")
+	}
+
+	union := view.Union(hashSetOf(9))
+	if !union.Add(10) || union.Size() != 5 {
+		t.Error("Set operations should return new mutable sets")
+	}
+	if setx.ReadOnly(view) != view {
+		t.Error("Wrapping a read-only view again should return it unchanged")
+	}
+}
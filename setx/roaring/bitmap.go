@@ -0,0 +1,366 @@
+// Package roaring provides a compressed set of uint32 based on Roaring bitmaps.
+//
+// Elements are partitioned by their high 16 bits into containers that store the low 16 bits
+// as a sorted array, a 65536-bit bitmap or a list of runs, whichever fits the data.
+// See https://roaringbitmap.org for the design and the serialization format.
+package roaring
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/gosuda/stdx/option"
+	"github.com/gosuda/stdx/result"
+	"github.com/gosuda/stdx/setx"
+)
+
+var _ setx.Set[uint32] = (*Bitmap)(nil)
+
+// Bitmap is a set of uint32 stored as a Roaring bitmap.
+//
+// Set operations with another *Bitmap work a container at a time; other setx.Set implementations
+// are handled element by element.
+type Bitmap struct {
+	keys       []uint16
+	containers []container
+}
+
+func New() *Bitmap {
+	return &Bitmap{}
+}
+
+// Of creates a Bitmap containing the given elements.
+func Of(elements ...uint32) *Bitmap {
+	b := New()
+	for _, element := range elements {
+		b.Add(element)
+	}
+	return b
+}
+
+func split(element uint32) (high, low uint16) {
+	return uint16(element >> 16), uint16(element)
+}
+
+// find returns the index of the container for key and whether it exists.
+func (b *Bitmap) find(key uint16) (int, bool) {
+	return slices.BinarySearch(b.keys, key)
+}
+
+// appendContainer adds c under key, which must be greater than every existing key. Empty containers are skipped.
+func (b *Bitmap) appendContainer(key uint16, c container) {
+	if c.cardinality() == 0 {
+		return
+	}
+	b.keys = append(b.keys, key)
+	b.containers = append(b.containers, c)
+}
+
+// Add implements setx.Set.
+func (b *Bitmap) Add(element uint32) bool {
+	high, low := split(element)
+	i, found := b.find(high)
+	if !found {
+		b.keys = slices.Insert(b.keys, i, high)
+		b.containers = slices.Insert(b.containers, i, container(&arrayContainer{values: []uint16{low}}))
+		return true
+	}
+	var added bool
+	b.containers[i], added = b.containers[i].add(low)
+	return added
+}
+
+// Clear implements setx.Set.
+func (b *Bitmap) Clear() {
+	b.keys = nil
+	b.containers = nil
+}
+
+// Contains implements setx.Set.
+func (b *Bitmap) Contains(element uint32) bool {
+	high, low := split(element)
+	i, found := b.find(high)
+	return found && b.containers[i].contains(low)
+}
+
+// Difference implements setx.Set. The result is a new *Bitmap.
+func (b *Bitmap) Difference(other setx.Set[uint32]) setx.Set[uint32] {
+	if o, ok := other.(*Bitmap); ok {
+		return b.AndNot(o)
+	}
+	return b.Filter(func(element uint32) bool {
+		return !other.Contains(element)
+	})
+}
+
+// Intersection implements setx.Set. The result is a new *Bitmap.
+func (b *Bitmap) Intersection(other setx.Set[uint32]) setx.Set[uint32] {
+	if o, ok := other.(*Bitmap); ok {
+		return b.And(o)
+	}
+	return b.Filter(other.Contains)
+}
+
+// IsEmpty implements setx.Set.
+func (b *Bitmap) IsEmpty() bool {
+	return len(b.containers) == 0
+}
+
+// IsSubsetOf implements setx.Set.
+func (b *Bitmap) IsSubsetOf(other setx.Set[uint32]) bool {
+	if o, ok := other.(*Bitmap); ok {
+		return b.AndCardinality(o) == b.Size()
+	}
+	for element := range b.All() {
+		if !other.Contains(element) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSupersetOf implements setx.Set.
+func (b *Bitmap) IsSupersetOf(other setx.Set[uint32]) bool {
+	return other.IsSubsetOf(b)
+}
+
+// Remove implements setx.Set.
+func (b *Bitmap) Remove(element uint32) bool {
+	high, low := split(element)
+	i, found := b.find(high)
+	if !found {
+		return false
+	}
+	var removed bool
+	b.containers[i], removed = b.containers[i].remove(low)
+	if removed && b.containers[i].cardinality() == 0 {
+		b.keys = slices.Delete(b.keys, i, i+1)
+		b.containers = slices.Delete(b.containers, i, i+1)
+	}
+	return removed
+}
+
+// Size implements setx.Set. It sums the container cardinalities without visiting the elements.
+func (b *Bitmap) Size() int {
+	size := 0
+	for _, c := range b.containers {
+		size += c.cardinality()
+	}
+	return size
+}
+
+// ToSlice implements setx.Set. Elements are returned in ascending order.
+func (b *Bitmap) ToSlice() []uint32 {
+	result := make([]uint32, 0, b.Size())
+	for element := range b.All() {
+		result = append(result, element)
+	}
+	return result
+}
+
+// ForEach implements setx.Set. Elements are visited in ascending order.
+func (b *Bitmap) ForEach(fn func(element uint32)) {
+	for element := range b.All() {
+		fn(element)
+	}
+}
+
+// Union implements setx.Set. The result is a new *Bitmap.
+func (b *Bitmap) Union(other setx.Set[uint32]) setx.Set[uint32] {
+	if o, ok := other.(*Bitmap); ok {
+		return b.Or(o)
+	}
+	result := b.Clone()
+	other.ForEach(func(element uint32) {
+		result.Add(element)
+	})
+	return result
+}
+
+// Find implements setx.Set. Returns the smallest matching element.
+func (b *Bitmap) Find(predicate func(uint32) bool) option.Option[uint32] {
+	for element := range b.All() {
+		if predicate(element) {
+			return option.Some(element)
+		}
+	}
+	return option.None[uint32]()
+}
+
+// GetAny implements setx.Set. Returns the smallest element.
+func (b *Bitmap) GetAny() option.Option[uint32] {
+	return b.Minimum()
+}
+
+// TryRemove implements setx.Set.
+func (b *Bitmap) TryRemove(element uint32) result.Result[uint32, error] {
+	if b.Remove(element) {
+		return result.Ok[uint32, error](element)
+	}
+	return result.Err[uint32, error](setx.ErrElementNotFound)
+}
+
+// Filter implements setx.Set. The result is a new *Bitmap.
+func (b *Bitmap) Filter(predicate func(uint32) bool) setx.Set[uint32] {
+	result := New()
+	for element := range b.All() {
+		if predicate(element) {
+			result.Add(element)
+		}
+	}
+	return result
+}
+
+// Minimum returns the smallest element, or None if the bitmap is empty.
+func (b *Bitmap) Minimum() option.Option[uint32] {
+	for element := range b.All() {
+		return option.Some(element)
+	}
+	return option.None[uint32]()
+}
+
+// Maximum returns the largest element, or None if the bitmap is empty.
+func (b *Bitmap) Maximum() option.Option[uint32] {
+	if len(b.containers) == 0 {
+		return option.None[uint32]()
+	}
+	var low uint16
+	b.containers[len(b.containers)-1].each(func(v uint16) bool {
+		low = v
+		return true
+	})
+	return option.Some(uint32(b.keys[len(b.keys)-1])<<16 | uint32(low))
+}
+
+// All returns an iterator over the elements in ascending order.
+// The bitmap must not be modified during iteration.
+func (b *Bitmap) All() iter.Seq[uint32] {
+	return func(yield func(uint32) bool) {
+		for i, c := range b.containers {
+			base := uint32(b.keys[i]) << 16
+			if !c.each(func(v uint16) bool { return yield(base | uint32(v)) }) {
+				return
+			}
+		}
+	}
+}
+
+// Clone returns an independent copy of the bitmap.
+func (b *Bitmap) Clone() *Bitmap {
+	result := &Bitmap{
+		keys:       slices.Clone(b.keys),
+		containers: make([]container, len(b.containers)),
+	}
+	for i, c := range b.containers {
+		result.containers[i] = c.clone()
+	}
+	return result
+}
+
+// RunOptimize converts each container to run encoding where that is smaller, and back where it is not.
+// Call it after bulk insertion of consecutive values to reduce memory use and serialized size.
+func (b *Bitmap) RunOptimize() {
+	for i, c := range b.containers {
+		b.containers[i] = optimize(c)
+	}
+}
+
+// And returns a new bitmap with the elements present in both b and other.
+func (b *Bitmap) And(other *Bitmap) *Bitmap {
+	result := New()
+	for i, j := 0, 0; i < len(b.keys) && j < len(other.keys); {
+		switch {
+		case b.keys[i] < other.keys[j]:
+			i++
+		case b.keys[i] > other.keys[j]:
+			j++
+		default:
+			result.appendContainer(b.keys[i], and(b.containers[i], other.containers[j]))
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// Or returns a new bitmap with the elements present in b or other.
+func (b *Bitmap) Or(other *Bitmap) *Bitmap {
+	return b.merge(other, or, true)
+}
+
+// Xor returns a new bitmap with the elements present in exactly one of b and other.
+func (b *Bitmap) Xor(other *Bitmap) *Bitmap {
+	return b.merge(other, xor, true)
+}
+
+// AndNot returns a new bitmap with the elements of b that are not in other.
+func (b *Bitmap) AndNot(other *Bitmap) *Bitmap {
+	return b.merge(other, andNot, false)
+}
+
+// merge walks both key lists, combining shared containers with op. Containers only in b are copied;
+// containers only in other are copied if keepOther is set.
+func (b *Bitmap) merge(other *Bitmap, op func(a, b container) container, keepOther bool) *Bitmap {
+	result := New()
+	i, j := 0, 0
+	for i < len(b.keys) && j < len(other.keys) {
+		switch {
+		case b.keys[i] < other.keys[j]:
+			result.appendContainer(b.keys[i], b.containers[i].clone())
+			i++
+		case b.keys[i] > other.keys[j]:
+			if keepOther {
+				result.appendContainer(other.keys[j], other.containers[j].clone())
+			}
+			j++
+		default:
+			result.appendContainer(b.keys[i], op(b.containers[i], other.containers[j]))
+			i++
+			j++
+		}
+	}
+	for ; i < len(b.keys); i++ {
+		result.appendContainer(b.keys[i], b.containers[i].clone())
+	}
+	for ; keepOther && j < len(other.keys); j++ {
+		result.appendContainer(other.keys[j], other.containers[j].clone())
+	}
+	return result
+}
+
+// AndCardinality returns the size of the intersection of b and other without building it.
+func (b *Bitmap) AndCardinality(other *Bitmap) int {
+	count := 0
+	for i, j := 0, 0; i < len(b.keys) && j < len(other.keys); {
+		switch {
+		case b.keys[i] < other.keys[j]:
+			i++
+		case b.keys[i] > other.keys[j]:
+			j++
+		default:
+			count += andCardinality(b.containers[i], other.containers[j])
+			i++
+			j++
+		}
+	}
+	return count
+}
+
+// OrCardinality returns the size of the union of b and other without building it.
+func (b *Bitmap) OrCardinality(other *Bitmap) int {
+	return b.Size() + other.Size() - b.AndCardinality(other)
+}
+
+// Equal reports whether b and other contain the same elements, regardless of container encoding.
+func (b *Bitmap) Equal(other *Bitmap) bool {
+	if !slices.Equal(b.keys, other.keys) {
+		return false
+	}
+	for i, c := range b.containers {
+		if c.cardinality() != other.containers[i].cardinality() ||
+			andCardinality(c, other.containers[i]) != c.cardinality() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,460 @@
+package roaring
+
+import (
+	"math/bits"
+	"slices"
+	"sort"
+)
+
+const (
+	// arrayMaxSize is the largest cardinality stored in an array container.
+	arrayMaxSize = 4096
+	// bitmapWords is the number of 64-bit words in a bitmap container.
+	bitmapWords = 1 << 16 / 64
+)
+
+// container holds the low 16 bits of the elements that share the same high 16 bits.
+//
+// Outside of run containers the representation follows the cardinality:
+// at most arrayMaxSize elements are kept in an array container, more in a bitmap container.
+type container interface {
+	contains(x uint16) bool
+	// add and remove may return a container of a different kind.
+	add(x uint16) (container, bool)
+	remove(x uint16) (container, bool)
+	cardinality() int
+	each(yield func(uint16) bool) bool
+	toBitmap() *bitmapContainer
+	clone() container
+}
+
+// arrayContainer stores a sorted slice of values.
+type arrayContainer struct {
+	values []uint16
+}
+
+func (a *arrayContainer) contains(x uint16) bool {
+	_, found := slices.BinarySearch(a.values, x)
+	return found
+}
+
+func (a *arrayContainer) add(x uint16) (container, bool) {
+	i, found := slices.BinarySearch(a.values, x)
+	if found {
+		return a, false
+	}
+	if len(a.values) == arrayMaxSize {
+		b := a.toBitmap()
+		b.add(x)
+		return b, true
+	}
+	a.values = slices.Insert(a.values, i, x)
+	return a, true
+}
+
+func (a *arrayContainer) remove(x uint16) (container, bool) {
+	i, found := slices.BinarySearch(a.values, x)
+	if !found {
+		return a, false
+	}
+	a.values = slices.Delete(a.values, i, i+1)
+	return a, true
+}
+
+func (a *arrayContainer) cardinality() int {
+	return len(a.values)
+}
+
+func (a *arrayContainer) each(yield func(uint16) bool) bool {
+	for _, v := range a.values {
+		if !yield(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *arrayContainer) toBitmap() *bitmapContainer {
+	b := newBitmapContainer()
+	for _, v := range a.values {
+		b.words[v/64] |= 1 << (v % 64)
+	}
+	b.card = len(a.values)
+	return b
+}
+
+func (a *arrayContainer) clone() container {
+	return &arrayContainer{values: slices.Clone(a.values)}
+}
+
+// bitmapContainer stores one bit per possible value.
+type bitmapContainer struct {
+	words []uint64
+	card  int
+}
+
+func newBitmapContainer() *bitmapContainer {
+	return &bitmapContainer{words: make([]uint64, bitmapWords)}
+}
+
+func (b *bitmapContainer) contains(x uint16) bool {
+	return b.words[x/64]&(1<<(x%64)) != 0
+}
+
+func (b *bitmapContainer) add(x uint16) (container, bool) {
+	mask := uint64(1) << (x % 64)
+	if b.words[x/64]&mask != 0 {
+		return b, false
+	}
+	b.words[x/64] |= mask
+	b.card++
+	return b, true
+}
+
+func (b *bitmapContainer) remove(x uint16) (container, bool) {
+	mask := uint64(1) << (x % 64)
+	if b.words[x/64]&mask == 0 {
+		return b, false
+	}
+	b.words[x/64] &^= mask
+	b.card--
+	if b.card <= arrayMaxSize {
+		return b.toArray(), true
+	}
+	return b, true
+}
+
+func (b *bitmapContainer) cardinality() int {
+	return b.card
+}
+
+func (b *bitmapContainer) each(yield func(uint16) bool) bool {
+	for i, w := range b.words {
+		for ; w != 0; w &= w - 1 {
+			if !yield(uint16(i*64 + bits.TrailingZeros64(w))) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (b *bitmapContainer) toBitmap() *bitmapContainer {
+	return b.clone().(*bitmapContainer)
+}
+
+func (b *bitmapContainer) clone() container {
+	return &bitmapContainer{words: slices.Clone(b.words), card: b.card}
+}
+
+func (b *bitmapContainer) toArray() *arrayContainer {
+	values := make([]uint16, 0, b.card)
+	b.each(func(v uint16) bool {
+		values = append(values, v)
+		return true
+	})
+	return &arrayContainer{values: values}
+}
+
+// setRange sets the bits lo through hi inclusive. It does not update card.
+func (b *bitmapContainer) setRange(lo, hi int) {
+	for lo <= hi {
+		if lo%64 == 0 && hi-lo >= 63 {
+			b.words[lo/64] = ^uint64(0)
+			lo += 64
+			continue
+		}
+		b.words[lo/64] |= 1 << (lo % 64)
+		lo++
+	}
+}
+
+// recount recomputes card from the words.
+func (b *bitmapContainer) recount() {
+	b.card = 0
+	for _, w := range b.words {
+		b.card += bits.OnesCount64(w)
+	}
+}
+
+// normalize returns b, or an equivalent array container if it is small enough.
+func (b *bitmapContainer) normalize() container {
+	if b.card <= arrayMaxSize {
+		return b.toArray()
+	}
+	return b
+}
+
+// interval is a run of consecutive values from start to last inclusive.
+type interval struct {
+	start, last uint16
+}
+
+// runContainer stores sorted, non-overlapping, non-adjacent runs of values.
+type runContainer struct {
+	runs []interval
+}
+
+// search returns the index of the run that may contain x, or -1 if x is below every run.
+func (r *runContainer) search(x uint16) int {
+	return sort.Search(len(r.runs), func(i int) bool { return r.runs[i].start > x }) - 1
+}
+
+func (r *runContainer) contains(x uint16) bool {
+	i := r.search(x)
+	return i >= 0 && x <= r.runs[i].last
+}
+
+func (r *runContainer) add(x uint16) (container, bool) {
+	i := r.search(x)
+	if i >= 0 && x <= r.runs[i].last {
+		return r, false
+	}
+	mergeLeft := i >= 0 && int(r.runs[i].last)+1 == int(x)
+	mergeRight := i+1 < len(r.runs) && int(r.runs[i+1].start) == int(x)+1
+	switch {
+	case mergeLeft && mergeRight:
+		r.runs[i].last = r.runs[i+1].last
+		r.runs = slices.Delete(r.runs, i+1, i+2)
+	case mergeLeft:
+		r.runs[i].last = x
+	case mergeRight:
+		r.runs[i+1].start = x
+	default:
+		r.runs = slices.Insert(r.runs, i+1, interval{start: x, last: x})
+	}
+	return r, true
+}
+
+func (r *runContainer) remove(x uint16) (container, bool) {
+	i := r.search(x)
+	if i < 0 || x > r.runs[i].last {
+		return r, false
+	}
+	run := r.runs[i]
+	switch {
+	case run.start == run.last:
+		r.runs = slices.Delete(r.runs, i, i+1)
+	case x == run.start:
+		r.runs[i].start++
+	case x == run.last:
+		r.runs[i].last--
+	default:
+		r.runs[i].last = x - 1
+		r.runs = slices.Insert(r.runs, i+1, interval{start: x + 1, last: run.last})
+	}
+	return r, true
+}
+
+func (r *runContainer) cardinality() int {
+	card := 0
+	for _, run := range r.runs {
+		card += int(run.last-run.start) + 1
+	}
+	return card
+}
+
+func (r *runContainer) each(yield func(uint16) bool) bool {
+	for _, run := range r.runs {
+		for v := int(run.start); v <= int(run.last); v++ {
+			if !yield(uint16(v)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (r *runContainer) toBitmap() *bitmapContainer {
+	b := newBitmapContainer()
+	for _, run := range r.runs {
+		b.setRange(int(run.start), int(run.last))
+	}
+	b.recount()
+	return b
+}
+
+func (r *runContainer) clone() container {
+	return &runContainer{runs: slices.Clone(r.runs)}
+}
+
+// countRuns returns the number of runs needed to encode c.
+func countRuns(c container) int {
+	if r, ok := c.(*runContainer); ok {
+		return len(r.runs)
+	}
+	runs, prev := 0, -2
+	c.each(func(v uint16) bool {
+		if int(v) != prev+1 {
+			runs++
+		}
+		prev = int(v)
+		return true
+	})
+	return runs
+}
+
+// toRun converts c to a run container.
+func toRun(c container) *runContainer {
+	if r, ok := c.(*runContainer); ok {
+		return r
+	}
+	r := &runContainer{runs: make([]interval, 0, countRuns(c))}
+	c.each(func(v uint16) bool {
+		if n := len(r.runs); n > 0 && int(r.runs[n-1].last)+1 == int(v) {
+			r.runs[n-1].last = v
+		} else {
+			r.runs = append(r.runs, interval{start: v, last: v})
+		}
+		return true
+	})
+	return r
+}
+
+// optimize returns the smallest serialized representation of c.
+func optimize(c container) container {
+	runSize := 2 + 4*countRuns(c)
+	size := 2 * c.cardinality()
+	if c.cardinality() > arrayMaxSize {
+		size = bitmapWords * 8
+	}
+	if runSize < size {
+		return toRun(c)
+	}
+	if _, ok := c.(*runContainer); ok {
+		return c.toBitmap().normalize()
+	}
+	return c
+}
+
+// and returns the intersection of a and b.
+func and(a, b container) container {
+	if x, ok := a.(*arrayContainer); ok {
+		return filterArray(x, b, true)
+	}
+	if y, ok := b.(*arrayContainer); ok {
+		return filterArray(y, a, true)
+	}
+	result := a.toBitmap()
+	other := bitmapOf(b)
+	for i := range result.words {
+		result.words[i] &= other.words[i]
+	}
+	result.recount()
+	return result.normalize()
+}
+
+// or returns the union of a and b.
+func or(a, b container) container {
+	x, aok := a.(*arrayContainer)
+	y, bok := b.(*arrayContainer)
+	if aok && bok && len(x.values)+len(y.values) <= arrayMaxSize {
+		values := make([]uint16, 0, len(x.values)+len(y.values))
+		i, j := 0, 0
+		for i < len(x.values) && j < len(y.values) {
+			switch {
+			case x.values[i] < y.values[j]:
+				values = append(values, x.values[i])
+				i++
+			case x.values[i] > y.values[j]:
+				values = append(values, y.values[j])
+				j++
+			default:
+				values = append(values, x.values[i])
+				i++
+				j++
+			}
+		}
+		values = append(values, x.values[i:]...)
+		values = append(values, y.values[j:]...)
+		return &arrayContainer{values: values}
+	}
+	result := a.toBitmap()
+	if y, ok := b.(*arrayContainer); ok {
+		for _, v := range y.values {
+			result.add(v)
+		}
+		return result.normalize()
+	}
+	other := bitmapOf(b)
+	for i := range result.words {
+		result.words[i] |= other.words[i]
+	}
+	result.recount()
+	return result.normalize()
+}
+
+// andNot returns the elements of a that are not in b.
+func andNot(a, b container) container {
+	if x, ok := a.(*arrayContainer); ok {
+		return filterArray(x, b, false)
+	}
+	result := a.toBitmap()
+	if y, ok := b.(*arrayContainer); ok {
+		for _, v := range y.values {
+			result.words[v/64] &^= 1 << (v % 64)
+		}
+	} else {
+		other := bitmapOf(b)
+		for i := range result.words {
+			result.words[i] &^= other.words[i]
+		}
+	}
+	result.recount()
+	return result.normalize()
+}
+
+// xor returns the elements that are in exactly one of a and b.
+func xor(a, b container) container {
+	result := a.toBitmap()
+	other := bitmapOf(b)
+	for i := range result.words {
+		result.words[i] ^= other.words[i]
+	}
+	result.recount()
+	return result.normalize()
+}
+
+// andCardinality returns the size of the intersection of a and b without building it.
+func andCardinality(a, b container) int {
+	x, aok := a.(*arrayContainer)
+	if !aok {
+		if y, ok := b.(*arrayContainer); ok {
+			x, aok, b = y, true, a
+		}
+	}
+	if aok {
+		count := 0
+		for _, v := range x.values {
+			if b.contains(v) {
+				count++
+			}
+		}
+		return count
+	}
+	left, right := bitmapOf(a), bitmapOf(b)
+	count := 0
+	for i := range left.words {
+		count += bits.OnesCount64(left.words[i] & right.words[i])
+	}
+	return count
+}
+
+// bitmapOf returns c as a bitmap container for reading, converting it only if needed.
+func bitmapOf(c container) *bitmapContainer {
+	if b, ok := c.(*bitmapContainer); ok {
+		return b
+	}
+	return c.toBitmap()
+}
+
+// filterArray keeps the values of a that are (keep) or are not (!keep) in other.
+func filterArray(a *arrayContainer, other container, keep bool) container {
+	values := make([]uint16, 0, len(a.values))
+	for _, v := range a.values {
+		if other.contains(v) == keep {
+			values = append(values, v)
+		}
+	}
+	return &arrayContainer{values: values}
+}
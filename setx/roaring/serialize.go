@@ -0,0 +1,199 @@
+package roaring
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// Cookies and thresholds of the portable Roaring serialization format.
+const (
+	serialCookieNoRun = 12346
+	serialCookie      = 12347
+	noOffsetThreshold = 4
+)
+
+// ErrInvalidFormat is returned by UnmarshalBinary when the data is not a valid Roaring bitmap.
+var ErrInvalidFormat = errors.New("roaring: invalid serialization format")
+
+// MarshalBinary implements encoding.BinaryMarshaler using the portable Roaring format,
+// which other Roaring implementations (CRoaring, Java, roaring for Go) can read.
+func (b *Bitmap) MarshalBinary() ([]byte, error) {
+	size := len(b.containers)
+	hasRuns := false
+	for _, c := range b.containers {
+		if _, ok := c.(*runContainer); ok {
+			hasRuns = true
+			break
+		}
+	}
+
+	var data []byte
+	if hasRuns {
+		data = binary.LittleEndian.AppendUint32(data, serialCookie|uint32(size-1)<<16)
+		runFlags := make([]byte, (size+7)/8)
+		for i, c := range b.containers {
+			if _, ok := c.(*runContainer); ok {
+				runFlags[i/8] |= 1 << (i % 8)
+			}
+		}
+		data = append(data, runFlags...)
+	} else {
+		data = binary.LittleEndian.AppendUint32(data, serialCookieNoRun)
+		data = binary.LittleEndian.AppendUint32(data, uint32(size))
+	}
+
+	for i, c := range b.containers {
+		data = binary.LittleEndian.AppendUint16(data, b.keys[i])
+		data = binary.LittleEndian.AppendUint16(data, uint16(c.cardinality()-1))
+	}
+
+	if !hasRuns || size >= noOffsetThreshold {
+		offset := len(data) + 4*size
+		for _, c := range b.containers {
+			data = binary.LittleEndian.AppendUint32(data, uint32(offset))
+			offset += serializedSize(c)
+		}
+	}
+
+	for _, c := range b.containers {
+		switch c := c.(type) {
+		case *arrayContainer:
+			for _, v := range c.values {
+				data = binary.LittleEndian.AppendUint16(data, v)
+			}
+		case *bitmapContainer:
+			for _, w := range c.words {
+				data = binary.LittleEndian.AppendUint64(data, w)
+			}
+		case *runContainer:
+			data = binary.LittleEndian.AppendUint16(data, uint16(len(c.runs)))
+			for _, run := range c.runs {
+				data = binary.LittleEndian.AppendUint16(data, run.start)
+				data = binary.LittleEndian.AppendUint16(data, run.last-run.start)
+			}
+		}
+	}
+	return data, nil
+}
+
+// serializedSize returns the number of bytes c occupies in the container section.
+func serializedSize(c container) int {
+	switch c := c.(type) {
+	case *arrayContainer:
+		return 2 * len(c.values)
+	case *runContainer:
+		return 2 + 4*len(c.runs)
+	default:
+		return 8 * bitmapWords
+	}
+}
+
+// reader consumes little-endian values from a byte slice, remembering whether it ran out of data.
+type reader struct {
+	data []byte
+	bad  bool
+}
+
+func (r *reader) bytes(n int) []byte {
+	if r.bad || n > len(r.data) {
+		r.bad = true
+		return nil
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
+
+func (r *reader) uint16() uint16 {
+	if b := r.bytes(2); b != nil {
+		return binary.LittleEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (r *reader) uint32() uint32 {
+	if b := r.bytes(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler for the portable Roaring format.
+// The current contents are replaced.
+func (b *Bitmap) UnmarshalBinary(data []byte) error {
+	r := &reader{data: data}
+	cookie := r.uint32()
+
+	var size int
+	var runFlags []byte
+	switch {
+	case r.bad:
+		return ErrInvalidFormat
+	case cookie&0xFFFF == serialCookie:
+		size = int(cookie>>16) + 1
+		runFlags = r.bytes((size + 7) / 8)
+	case cookie == serialCookieNoRun:
+		size = int(r.uint32())
+		if size > 1<<16 {
+			return ErrInvalidFormat
+		}
+	default:
+		return ErrInvalidFormat
+	}
+
+	keys := make([]uint16, size)
+	cards := make([]int, size)
+	for i := range size {
+		keys[i] = r.uint16()
+		cards[i] = int(r.uint16()) + 1
+		if i > 0 && keys[i] <= keys[i-1] {
+			return ErrInvalidFormat
+		}
+	}
+	if runFlags == nil || size >= noOffsetThreshold {
+		// Containers are stored back to back, so the offsets are not needed for sequential reading.
+		r.bytes(4 * size)
+	}
+
+	containers := make([]container, size)
+	for i := range size {
+		switch {
+		case runFlags != nil && runFlags[i/8]&(1<<(i%8)) != 0:
+			c := &runContainer{runs: make([]interval, r.uint16())}
+			for j := range c.runs {
+				start, length := r.uint16(), r.uint16()
+				if int(start)+int(length) > 0xFFFF || (j > 0 && int(start) <= int(c.runs[j-1].last)+1) {
+					return ErrInvalidFormat
+				}
+				c.runs[j] = interval{start: start, last: start + length}
+			}
+			containers[i] = c
+		case cards[i] > arrayMaxSize:
+			c := newBitmapContainer()
+			raw := r.bytes(8 * bitmapWords)
+			for j := range c.words {
+				if raw != nil {
+					c.words[j] = binary.LittleEndian.Uint64(raw[8*j:])
+				}
+			}
+			c.recount()
+			containers[i] = c
+		default:
+			c := &arrayContainer{values: make([]uint16, cards[i])}
+			for j := range c.values {
+				c.values[j] = r.uint16()
+				if j > 0 && c.values[j] <= c.values[j-1] {
+					return ErrInvalidFormat
+				}
+			}
+			containers[i] = c
+		}
+		if r.bad || containers[i].cardinality() != cards[i] {
+			return ErrInvalidFormat
+		}
+	}
+
+	b.keys = keys
+	b.containers = containers
+	return nil
+}
@@ -0,0 +1,85 @@
+package roaring_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/gosuda/stdx/setx/roaring"
+)
+
+func TestBitmap_MarshalArray(t *testing.T) {
+	data, err := roaring.Of(1, 2, 3).MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+	expected := []byte{
+		0x3A, 0x30, 0, 0, // cookie without run containers
+		1, 0, 0, 0, // one container
+		0, 0, 2, 0, // key 0, cardinality 3
+		16, 0, 0, 0, // offset of the first container
+		1, 0, 2, 0, 3, 0,
+	}
+	if !bytes.Equal(data, expected) {
+		t.Errorf("Expected % x, got % x", expected, data)
+	}
+}
+
+func TestBitmap_MarshalRun(t *testing.T) {
+	b := roaring.New()
+	for i := uint32(1); i <= 100; i++ {
+		b.Add(i)
+	}
+	b.RunOptimize()
+
+	data, err := b.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+	expected := []byte{
+		0x3B, 0x30, 0, 0, // cookie with run containers, one container
+		1,           // run flags
+		0, 0, 99, 0, // key 0, cardinality 100
+		1, 0, // one run
+		1, 0, 99, 0, // start 1, length 99
+	}
+	if !bytes.Equal(data, expected) {
+		t.Errorf("Expected % x, got % x", expected, data)
+	}
+}
+
+func TestBitmap_BinaryRoundTrip(t *testing.T) {
+	for name, b := range shapes() {
+		data, err := b.MarshalBinary()
+		if err != nil {
+			t.Fatalf("%s: MarshalBinary failed: %v", name, err)
+		}
+		decoded := roaring.Of(42)
+		if err := decoded.UnmarshalBinary(data); err != nil {
+			t.Fatalf("%s: UnmarshalBinary failed: %v", name, err)
+		}
+		if !decoded.Equal(b) {
+			t.Errorf("%s: round trip changed the contents", name)
+		}
+	}
+
+	empty, _ := roaring.New().MarshalBinary()
+	decoded := roaring.Of(1)
+	if err := decoded.UnmarshalBinary(empty); err != nil || !decoded.IsEmpty() {
+		t.Errorf("Empty bitmap should round trip, got %v", err)
+	}
+}
+
+func TestBitmap_UnmarshalInvalid(t *testing.T) {
+	valid, _ := roaring.Of(1, 2, 3).MarshalBinary()
+	for name, data := range map[string][]byte{
+		"empty":     nil,
+		"cookie":    {1, 2, 3, 4, 0, 0, 0, 0},
+		"truncated": valid[:len(valid)-1],
+		"unsorted":  append(valid[:16:16], 3, 0, 2, 0, 1, 0),
+	} {
+		if err := roaring.New().UnmarshalBinary(data); !errors.Is(err, roaring.ErrInvalidFormat) {
+			t.Errorf("%s: expected ErrInvalidFormat, got %v", name, err)
+		}
+	}
+}
@@ -1,10 +1,15 @@
 package setx
 
 import (
+	"errors"
+
 	"github.com/gosuda/stdx/option"
 	"github.com/gosuda/stdx/result"
 )
 
+// ErrElementNotFound is returned when removing an element that is not in a set.
+var ErrElementNotFound = errors.New("element not found in set")
+
 // Set interface defines basic operations for set data structures.
 type Set[T comparable] interface {
 	// Add adds an element to the set. Returns false if it already exists, true if newly added.
@@ -0,0 +1,325 @@
+// Package treeset provides a sorted set ordered by a comparator.
+package treeset
+
+import (
+	"cmp"
+	"errors"
+	"iter"
+
+	"github.com/gosuda/stdx/option"
+	"github.com/gosuda/stdx/result"
+	"github.com/gosuda/stdx/setx"
+)
+
+var _ setx.Set[int] = (*TreeSet[int])(nil)
+
+// ErrOutOfRange is panicked with when adding an element outside the range of a view.
+var ErrOutOfRange = errors.New("treeset: element out of view range")
+
+// bound is an optional endpoint of a view.
+type bound[T any] struct {
+	value T
+	set   bool
+}
+
+// TreeSet is a set whose elements are kept sorted by a comparator.
+// Two elements are considered the same if the comparator returns 0 for them.
+//
+// HeadSet, TailSet and SubSet return views backed by the same tree: changes to a view
+// are visible in the set and vice versa. A view covers the half-open range [from, to).
+type TreeSet[T comparable] struct {
+	t  *tree[T]
+	lo bound[T] // inclusive
+	hi bound[T] // exclusive
+}
+
+// New creates a TreeSet ordered by the natural order of T.
+func New[T cmp.Ordered]() *TreeSet[T] {
+	return NewWithComparator[T](cmp.Compare[T])
+}
+
+// NewWithComparator creates a TreeSet ordered by compare, which returns
+// a negative number, zero or a positive number when a is less than, equal to or greater than b.
+func NewWithComparator[T comparable](compare func(a, b T) int) *TreeSet[T] {
+	return &TreeSet[T]{t: &tree[T]{cmp: compare}}
+}
+
+// empty returns a new, independent set with the same comparator.
+func (s *TreeSet[T]) empty() *TreeSet[T] {
+	return NewWithComparator(s.t.cmp)
+}
+
+// isView reports whether the set is restricted to a range.
+func (s *TreeSet[T]) isView() bool {
+	return s.lo.set || s.hi.set
+}
+
+// tooLow reports whether value is below the view's lower bound.
+func (s *TreeSet[T]) tooLow(value T) bool {
+	return s.lo.set && s.t.cmp(value, s.lo.value) < 0
+}
+
+// tooHigh reports whether value is at or above the view's upper bound.
+func (s *TreeSet[T]) tooHigh(value T) bool {
+	return s.hi.set && s.t.cmp(value, s.hi.value) >= 0
+}
+
+// inRange reports whether value is inside the view.
+func (s *TreeSet[T]) inRange(value T) bool {
+	return !s.tooLow(value) && !s.tooHigh(value)
+}
+
+// valueOf returns Some(n.value) if n is non-nil and in range, None otherwise.
+func (s *TreeSet[T]) valueOf(n *treeNode[T]) option.Option[T] {
+	if n == nil || !s.inRange(n.value) {
+		return option.None[T]()
+	}
+	return option.Some(n.value)
+}
+
+// Add implements setx.Set. Panics with ErrOutOfRange if element is outside the view.
+func (s *TreeSet[T]) Add(element T) bool {
+	if !s.inRange(element) {
+		panic(ErrOutOfRange)
+	}
+	return s.t.insert(element)
+}
+
+// Clear implements setx.Set. On a view only the elements in range are removed.
+func (s *TreeSet[T]) Clear() {
+	if !s.isView() {
+		s.t.root = nil
+		s.t.size = 0
+		return
+	}
+	for _, element := range s.ToSlice() {
+		s.t.delete(element)
+	}
+}
+
+// Contains implements setx.Set.
+func (s *TreeSet[T]) Contains(element T) bool {
+	return s.inRange(element) && s.t.find(element) != nil
+}
+
+// Difference implements setx.Set. The result is a new TreeSet with the same comparator.
+func (s *TreeSet[T]) Difference(other setx.Set[T]) setx.Set[T] {
+	return s.Filter(func(element T) bool {
+		return !other.Contains(element)
+	})
+}
+
+// Intersection implements setx.Set. The result is a new TreeSet with the same comparator.
+func (s *TreeSet[T]) Intersection(other setx.Set[T]) setx.Set[T] {
+	return s.Filter(other.Contains)
+}
+
+// IsEmpty implements setx.Set.
+func (s *TreeSet[T]) IsEmpty() bool {
+	return s.First().IsNone()
+}
+
+// IsSubsetOf implements setx.Set.
+func (s *TreeSet[T]) IsSubsetOf(other setx.Set[T]) bool {
+	for element := range s.All() {
+		if !other.Contains(element) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSupersetOf implements setx.Set.
+func (s *TreeSet[T]) IsSupersetOf(other setx.Set[T]) bool {
+	return other.IsSubsetOf(s)
+}
+
+// Remove implements setx.Set.
+func (s *TreeSet[T]) Remove(element T) bool {
+	return s.inRange(element) && s.t.delete(element)
+}
+
+// Size implements setx.Set. On a view the elements in range are counted on every call.
+func (s *TreeSet[T]) Size() int {
+	if !s.isView() {
+		return s.t.size
+	}
+	count := 0
+	for range s.All() {
+		count++
+	}
+	return count
+}
+
+// ToSlice implements setx.Set. Elements are returned in ascending order.
+func (s *TreeSet[T]) ToSlice() []T {
+	var result []T
+	for element := range s.All() {
+		result = append(result, element)
+	}
+	return result
+}
+
+// ForEach implements setx.Set. Elements are visited in ascending order.
+func (s *TreeSet[T]) ForEach(fn func(element T)) {
+	for element := range s.All() {
+		fn(element)
+	}
+}
+
+// Union implements setx.Set. The result is a new TreeSet with the same comparator.
+func (s *TreeSet[T]) Union(other setx.Set[T]) setx.Set[T] {
+	result := s.empty()
+	s.ForEach(func(element T) {
+		result.Add(element)
+	})
+	other.ForEach(func(element T) {
+		result.Add(element)
+	})
+	return result
+}
+
+// Find implements setx.Set. Returns the smallest matching element.
+func (s *TreeSet[T]) Find(predicate func(T) bool) option.Option[T] {
+	for element := range s.All() {
+		if predicate(element) {
+			return option.Some(element)
+		}
+	}
+	return option.None[T]()
+}
+
+// GetAny implements setx.Set. Returns the smallest element.
+func (s *TreeSet[T]) GetAny() option.Option[T] {
+	return s.First()
+}
+
+// TryRemove implements setx.Set.
+func (s *TreeSet[T]) TryRemove(element T) result.Result[T, error] {
+	if s.Remove(element) {
+		return result.Ok[T, error](element)
+	}
+	return result.Err[T, error](setx.ErrElementNotFound)
+}
+
+// Filter implements setx.Set. The result is a new TreeSet with the same comparator.
+func (s *TreeSet[T]) Filter(predicate func(T) bool) setx.Set[T] {
+	result := s.empty()
+	for element := range s.All() {
+		if predicate(element) {
+			result.t.insert(element)
+		}
+	}
+	return result
+}
+
+// First returns the smallest element, or None if the set is empty.
+func (s *TreeSet[T]) First() option.Option[T] {
+	if s.lo.set {
+		return s.valueOf(s.t.ceiling(s.lo.value))
+	}
+	return s.valueOf(s.t.min())
+}
+
+// Last returns the largest element, or None if the set is empty.
+func (s *TreeSet[T]) Last() option.Option[T] {
+	if s.hi.set {
+		return s.valueOf(s.t.lower(s.hi.value))
+	}
+	return s.valueOf(s.t.max())
+}
+
+// Floor returns the largest element less than or equal to element, or None if there is none.
+func (s *TreeSet[T]) Floor(element T) option.Option[T] {
+	if s.tooHigh(element) {
+		return s.Last()
+	}
+	return s.valueOf(s.t.floor(element))
+}
+
+// Ceiling returns the smallest element greater than or equal to element, or None if there is none.
+func (s *TreeSet[T]) Ceiling(element T) option.Option[T] {
+	if s.tooLow(element) {
+		return s.First()
+	}
+	return s.valueOf(s.t.ceiling(element))
+}
+
+// HeadSet returns a view of the elements strictly less than to.
+func (s *TreeSet[T]) HeadSet(to T) *TreeSet[T] {
+	return s.view(bound[T]{}, bound[T]{value: to, set: true})
+}
+
+// TailSet returns a view of the elements greater than or equal to from.
+func (s *TreeSet[T]) TailSet(from T) *TreeSet[T] {
+	return s.view(bound[T]{value: from, set: true}, bound[T]{})
+}
+
+// SubSet returns a view of the elements greater than or equal to from and strictly less than to.
+func (s *TreeSet[T]) SubSet(from, to T) *TreeSet[T] {
+	return s.view(bound[T]{value: from, set: true}, bound[T]{value: to, set: true})
+}
+
+// view returns a view narrowed to the intersection of the receiver's range and [lo, hi).
+func (s *TreeSet[T]) view(lo, hi bound[T]) *TreeSet[T] {
+	v := &TreeSet[T]{t: s.t, lo: s.lo, hi: s.hi}
+	if lo.set && (!v.lo.set || s.t.cmp(lo.value, v.lo.value) > 0) {
+		v.lo = lo
+	}
+	if hi.set && (!v.hi.set || s.t.cmp(hi.value, v.hi.value) < 0) {
+		v.hi = hi
+	}
+	return v
+}
+
+// All returns an iterator over the elements in ascending order.
+// The set must not be modified during iteration.
+func (s *TreeSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.ascend(s.t.root, yield)
+	}
+}
+
+// Backward returns an iterator over the elements in descending order.
+// The set must not be modified during iteration.
+func (s *TreeSet[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.descend(s.t.root, yield)
+	}
+}
+
+// ascend visits the in-range values of the subtree rooted at n in ascending order.
+func (s *TreeSet[T]) ascend(n *treeNode[T], yield func(T) bool) bool {
+	if n == nil {
+		return true
+	}
+	low, high := s.tooLow(n.value), s.tooHigh(n.value)
+	if !low && !s.ascend(n.left, yield) {
+		return false
+	}
+	if !low && !high && !yield(n.value) {
+		return false
+	}
+	if !high {
+		return s.ascend(n.right, yield)
+	}
+	return true
+}
+
+// descend visits the in-range values of the subtree rooted at n in descending order.
+func (s *TreeSet[T]) descend(n *treeNode[T], yield func(T) bool) bool {
+	if n == nil {
+		return true
+	}
+	low, high := s.tooLow(n.value), s.tooHigh(n.value)
+	if !high && !s.descend(n.right, yield) {
+		return false
+	}
+	if !low && !high && !yield(n.value) {
+		return false
+	}
+	if !low {
+		return s.descend(n.left, yield)
+	}
+	return true
+}
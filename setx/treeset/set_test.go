@@ -0,0 +1,627 @@
+package treeset_test
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/gosuda/stdx/setx"
+	"github.com/gosuda/stdx/setx/treeset"
+)
+
+// createTreeSet is a factory function for creating TreeSet instances
+func createTreeSet[T cmp.Ordered]() setx.Set[T] {
+	return treeset.New[T]()
+}
+
+func TestTreeSet_Add(t *testing.T) {
+	testSetAdd(t, createTreeSet[int])
+}
+
+func TestTreeSet_Remove(t *testing.T) {
+	testSetRemove(t, createTreeSet[int])
+}
+
+func TestTreeSet_Contains(t *testing.T) {
+	testSetContains(t, createTreeSet[int])
+}
+
+func TestTreeSet_Size(t *testing.T) {
+	testSetSize(t, createTreeSet[int])
+}
+
+func TestTreeSet_IsEmpty(t *testing.T) {
+	testSetIsEmpty(t, createTreeSet[int])
+}
+
+func TestTreeSet_Clear(t *testing.T) {
+	testSetClear(t, createTreeSet[int])
+}
+
+func TestTreeSet_ToSlice(t *testing.T) {
+	testSetToSlice(t, createTreeSet[int])
+}
+
+func TestTreeSet_ForEach(t *testing.T) {
+	testSetForEach(t, createTreeSet[int])
+}
+
+func TestTreeSet_Union(t *testing.T) {
+	testSetUnion(t, createTreeSet[int])
+}
+
+func TestTreeSet_Intersection(t *testing.T) {
+	testSetIntersection(t, createTreeSet[int])
+}
+
+func TestTreeSet_Difference(t *testing.T) {
+	testSetDifference(t, createTreeSet[int])
+}
+
+func TestTreeSet_IsSubsetOf(t *testing.T) {
+	testSetIsSubsetOf(t, createTreeSet[int])
+}
+
+func TestTreeSet_IsSupersetOf(t *testing.T) {
+	testSetIsSupersetOf(t, createTreeSet[int])
+}
+
+func TestTreeSet_Find(t *testing.T) {
+	testSetFind(t, createTreeSet[int])
+}
+
+func TestTreeSet_GetAny(t *testing.T) {
+	testSetGetAny(t, createTreeSet[int])
+}
+
+func TestTreeSet_TryRemove(t *testing.T) {
+	testSetTryRemove(t, createTreeSet[int])
+}
+
+func TestTreeSet_Filter(t *testing.T) {
+	testSetFilter(t, createTreeSet[int])
+}
+
+func TestTreeSet_SortedOrder(t *testing.T) {
+	set := treeset.New[int]()
+	for _, v := range []int{50, 20, 80, 10, 30, 70, 90, 60, 40} {
+		set.Add(v)
+	}
+
+	expected := []int{10, 20, 30, 40, 50, 60, 70, 80, 90}
+	if got := set.ToSlice(); !slices.Equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	backward := slices.Collect(set.Backward())
+	slices.Reverse(backward)
+	if !slices.Equal(backward, expected) {
+		t.Errorf("Backward should be the reverse of %v, got %v", expected, backward)
+	}
+
+	if set.First().Unwrap() != 10 || set.Last().Unwrap() != 90 || set.GetAny().Unwrap() != 10 {
+		t.Error("Unexpected first or last element")
+	}
+}
+
+func TestTreeSet_FloorCeiling(t *testing.T) {
+	set := treeset.New[int]()
+	for _, v := range []int{10, 20, 30} {
+		set.Add(v)
+	}
+
+	cases := []struct {
+		value   int
+		floor   int
+		ceiling int
+	}{
+		{5, -1, 10},
+		{10, 10, 10},
+		{15, 10, 20},
+		{30, 30, 30},
+		{35, 30, -1},
+	}
+	for _, c := range cases {
+		if got := set.Floor(c.value).UnwrapOr(-1); got != c.floor {
+			t.Errorf("Floor(%d): expected %d, got %d", c.value, c.floor, got)
+		}
+		if got := set.Ceiling(c.value).UnwrapOr(-1); got != c.ceiling {
+			t.Errorf("Ceiling(%d): expected %d, got %d", c.value, c.ceiling, got)
+		}
+	}
+}
+
+func TestTreeSet_Views(t *testing.T) {
+	set := treeset.New[int]()
+	for i := 1; i <= 10; i++ {
+		set.Add(i * 10)
+	}
+
+	head := set.HeadSet(40)
+	if got := head.ToSlice(); !slices.Equal(got, []int{10, 20, 30}) {
+		t.Errorf("Unexpected head set %v", got)
+	}
+	tail := set.TailSet(80)
+	if got := tail.ToSlice(); !slices.Equal(got, []int{80, 90, 100}) {
+		t.Errorf("Unexpected tail set %v", got)
+	}
+	sub := set.SubSet(35, 75)
+	if got := sub.ToSlice(); !slices.Equal(got, []int{40, 50, 60, 70}) {
+		t.Errorf("Unexpected sub set %v", got)
+	}
+	if sub.Size() != 4 || sub.First().Unwrap() != 40 || sub.Last().Unwrap() != 70 {
+		t.Error("Unexpected sub set bounds")
+	}
+	if sub.Floor(100).Unwrap() != 70 || sub.Ceiling(0).Unwrap() != 40 || sub.Floor(30).IsSome() {
+		t.Error("Floor and Ceiling should respect view bounds")
+	}
+	if sub.Contains(80) || sub.Remove(80) {
+		t.Error("View should not see elements outside its range")
+	}
+
+	// Views are backed by the set.
+	set.Add(45)
+	if !sub.Contains(45) {
+		t.Error("View should see elements added to the set")
+	}
+	sub.Add(55)
+	sub.Remove(40)
+	if !set.Contains(55) || set.Contains(40) {
+		t.Error("Set should see changes made through the view")
+	}
+
+	// Nested views intersect their ranges.
+	nested := sub.HeadSet(90).TailSet(50)
+	if got := nested.ToSlice(); !slices.Equal(got, []int{50, 55, 60, 70}) {
+		t.Errorf("Unexpected nested view %v", got)
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r != treeset.ErrOutOfRange {
+				t.Errorf("Adding outside a view should panic with ErrOutOfRange, got %v", r)
+			}
+		}()
+		sub.Add(100)
+	}()
+
+	sub.Clear()
+	if !sub.IsEmpty() || set.Size() != 6 {
+		t.Errorf("Clearing a view should only remove its elements, %v left", set.ToSlice())
+	}
+}
+
+func TestTreeSet_Comparator(t *testing.T) {
+	set := treeset.NewWithComparator(func(a, b string) int {
+		return cmp.Compare(len(a), len(b))
+	})
+	set.Add("ccc")
+	set.Add("a")
+	set.Add("bb")
+
+	if got := set.ToSlice(); !slices.Equal(got, []string{"a", "bb", "ccc"}) {
+		t.Errorf("Expected elements ordered by length, got %v", got)
+	}
+	if set.Add("zz") {
+		t.Error("Elements comparing equal should be treated as duplicates")
+	}
+}
+
+func TestTreeSet_Balanced(t *testing.T) {
+	set := treeset.New[int]()
+	const n = 10000
+	for i := 0; i < n; i++ {
+		set.Add(i)
+	}
+	for i := 0; i < n; i += 2 {
+		set.Remove(i)
+	}
+
+	if set.Size() != n/2 {
+		t.Fatalf("Expected size %d, got %d", n/2, set.Size())
+	}
+	prev := -1
+	for v := range set.All() {
+		if v <= prev || v%2 == 0 {
+			t.Fatalf("Unexpected element %d after %d", v, prev)
+		}
+		prev = v
+	}
+}
+
+// Common test functions that can be reused for any Set implementation
+
+func testSetAdd(t *testing.T, factory func() setx.Set[int]) {
+	set := factory()
+
+	// Test adding new element
+	if !set.Add(1) {
+		t.Error("Add(1) should return true for new element")
+	}
+	if !set.Contains(1) {
+		t.Error("Set should contain 1 after adding")
+	}
+
+	// Test adding duplicate element
+	if set.Add(1) {
+		t.Error("Add(1) should return false for duplicate element")
+	}
+
+	// Test size after additions
+	set.Add(2)
+	set.Add(3)
+	if set.Size() != 3 {
+		t.Errorf("Expected size 3, got %d", set.Size())
+	}
+}
+
+func testSetRemove(t *testing.T, factory func() setx.Set[int]) {
+	set := factory()
+	set.Add(1)
+	set.Add(2)
+	set.Add(3)
+
+	// Test removing existing element
+	if !set.Remove(2) {
+		t.Error("Remove(2) should return true for existing element")
+	}
+	if set.Contains(2) {
+		t.Error("Set should not contain 2 after removal")
+	}
+
+	// Test removing non-existing element
+	if set.Remove(4) {
+		t.Error("Remove(4) should return false for non-existing element")
+	}
+
+	// Test size after removal
+	if set.Size() != 2 {
+		t.Errorf("Expected size 2, got %d", set.Size())
+	}
+}
+
+func testSetContains(t *testing.T, factory func() setx.Set[int]) {
+	set := factory()
+	set.Add(1)
+	set.Add(2)
+
+	if !set.Contains(1) {
+		t.Error("Set should contain 1")
+	}
+	if !set.Contains(2) {
+		t.Error("Set should contain 2")
+	}
+	if set.Contains(3) {
+		t.Error("Set should not contain 3")
+	}
+}
+
+func testSetSize(t *testing.T, factory func() setx.Set[int]) {
+	set := factory()
+
+	if set.Size() != 0 {
+		t.Errorf("Empty set size should be 0, got %d", set.Size())
+	}
+
+	set.Add(1)
+	if set.Size() != 1 {
+		t.Errorf("Size should be 1, got %d", set.Size())
+	}
+
+	set.Add(2)
+	set.Add(3)
+	if set.Size() != 3 {
+		t.Errorf("Size should be 3, got %d", set.Size())
+	}
+
+	set.Remove(2)
+	if set.Size() != 2 {
+		t.Errorf("Size should be 2 after removal, got %d", set.Size())
+	}
+}
+
+func testSetIsEmpty(t *testing.T, factory func() setx.Set[int]) {
+	set := factory()
+
+	if !set.IsEmpty() {
+		t.Error("New set should be empty")
+	}
+
+	set.Add(1)
+	if set.IsEmpty() {
+		t.Error("Set with elements should not be empty")
+	}
+
+	set.Remove(1)
+	if !set.IsEmpty() {
+		t.Error("Set should be empty after removing all elements")
+	}
+}
+
+func testSetClear(t *testing.T, factory func() setx.Set[int]) {
+	set := factory()
+	set.Add(1)
+	set.Add(2)
+	set.Add(3)
+
+	set.Clear()
+
+	if !set.IsEmpty() {
+		t.Error("Set should be empty after Clear()")
+	}
+	if set.Size() != 0 {
+		t.Errorf("Size should be 0 after Clear(), got %d", set.Size())
+	}
+	if set.Contains(1) || set.Contains(2) || set.Contains(3) {
+		t.Error("Set should not contain any elements after Clear()")
+	}
+}
+
+func testSetToSlice(t *testing.T, factory func() setx.Set[int]) {
+	set := factory()
+	set.Add(1)
+	set.Add(2)
+	set.Add(3)
+
+	slice := set.ToSlice()
+
+	if len(slice) != 3 {
+		t.Errorf("Expected slice length 3, got %d", len(slice))
+	}
+
+	// Check all elements are present (order doesn't matter)
+	found := make(map[int]bool)
+	for _, v := range slice {
+		found[v] = true
+	}
+
+	if !found[1] || !found[2] || !found[3] {
+		t.Error("ToSlice() should contain all set elements")
+	}
+}
+
+func testSetForEach(t *testing.T, factory func() setx.Set[int]) {
+	set := factory()
+	set.Add(1)
+	set.Add(2)
+	set.Add(3)
+
+	visited := make(map[int]bool)
+	set.ForEach(func(element int) {
+		visited[element] = true
+	})
+
+	if len(visited) != 3 {
+		t.Errorf("Expected to visit 3 elements, visited %d", len(visited))
+	}
+
+	if !visited[1] || !visited[2] || !visited[3] {
+		t.Error("ForEach should visit all elements")
+	}
+}
+
+func testSetUnion(t *testing.T, factory func() setx.Set[int]) {
+	set1 := factory()
+	set2 := factory()
+
+	set1.Add(1)
+	set1.Add(2)
+	set2.Add(2)
+	set2.Add(3)
+
+	union := set1.Union(set2)
+
+	if union.Size() != 3 {
+		t.Errorf("Union size should be 3, got %d", union.Size())
+	}
+
+	if !union.Contains(1) || !union.Contains(2) || !union.Contains(3) {
+		t.Error("Union should contain elements from both sets")
+	}
+}
+
+func testSetIntersection(t *testing.T, factory func() setx.Set[int]) {
+	set1 := factory()
+	set2 := factory()
+
+	set1.Add(1)
+	set1.Add(2)
+	set1.Add(3)
+	set2.Add(2)
+	set2.Add(3)
+	set2.Add(4)
+
+	intersection := set1.Intersection(set2)
+
+	if intersection.Size() != 2 {
+		t.Errorf("Intersection size should be 2, got %d", intersection.Size())
+	}
+
+	if !intersection.Contains(2) || !intersection.Contains(3) {
+		t.Error("Intersection should contain common elements")
+	}
+
+	if intersection.Contains(1) || intersection.Contains(4) {
+		t.Error("Intersection should not contain non-common elements")
+	}
+}
+
+func testSetDifference(t *testing.T, factory func() setx.Set[int]) {
+	set1 := factory()
+	set2 := factory()
+
+	set1.Add(1)
+	set1.Add(2)
+	set1.Add(3)
+	set2.Add(2)
+	set2.Add(4)
+
+	difference := set1.Difference(set2)
+
+	if difference.Size() != 2 {
+		t.Errorf("Difference size should be 2, got %d", difference.Size())
+	}
+
+	if !difference.Contains(1) || !difference.Contains(3) {
+		t.Error("Difference should contain elements only in first set")
+	}
+
+	if difference.Contains(2) || difference.Contains(4) {
+		t.Error("Difference should not contain common or second set only elements")
+	}
+}
+
+func testSetIsSubsetOf(t *testing.T, factory func() setx.Set[int]) {
+	set1 := factory()
+	set2 := factory()
+
+	set1.Add(1)
+	set1.Add(2)
+	set2.Add(1)
+	set2.Add(2)
+	set2.Add(3)
+
+	if !set1.IsSubsetOf(set2) {
+		t.Error("set1 should be subset of set2")
+	}
+
+	if set2.IsSubsetOf(set1) {
+		t.Error("set2 should not be subset of set1")
+	}
+
+	// Test with empty set
+	emptySet := factory()
+	if !emptySet.IsSubsetOf(set1) {
+		t.Error("Empty set should be subset of any set")
+	}
+}
+
+func testSetIsSupersetOf(t *testing.T, factory func() setx.Set[int]) {
+	set1 := factory()
+	set2 := factory()
+
+	set1.Add(1)
+	set1.Add(2)
+	set1.Add(3)
+	set2.Add(1)
+	set2.Add(2)
+
+	if !set1.IsSupersetOf(set2) {
+		t.Error("set1 should be superset of set2")
+	}
+
+	if set2.IsSupersetOf(set1) {
+		t.Error("set2 should not be superset of set1")
+	}
+
+	// Test with empty set
+	emptySet := factory()
+	if !set1.IsSupersetOf(emptySet) {
+		t.Error("Any set should be superset of empty set")
+	}
+}
+
+// Test functions for new Option/Result-based methods
+
+func testSetFind(t *testing.T, factory func() setx.Set[int]) {
+	set := factory()
+	set.Add(1)
+	set.Add(2)
+	set.Add(3)
+	set.Add(4)
+	set.Add(5)
+
+	// Find even number
+	result := set.Find(func(x int) bool { return x%2 == 0 })
+	if result.IsNone() {
+		t.Error("Should find an even number")
+	}
+
+	value := result.Unwrap()
+	if value%2 != 0 {
+		t.Errorf("Found value should be even, got %d", value)
+	}
+
+	// Find number greater than 10 (should not exist)
+	result = set.Find(func(x int) bool { return x > 10 })
+	if result.IsSome() {
+		t.Error("Should not find number greater than 10")
+	}
+}
+
+func testSetGetAny(t *testing.T, factory func() setx.Set[int]) {
+	set := factory()
+
+	// Empty set
+	result := set.GetAny()
+	if result.IsSome() {
+		t.Error("Empty set should return None")
+	}
+
+	// Non-empty set
+	set.Add(42)
+	result = set.GetAny()
+	if result.IsNone() {
+		t.Error("Non-empty set should return Some")
+	}
+	if result.Unwrap() != 42 {
+		t.Errorf("Expected 42, got %d", result.Unwrap())
+	}
+}
+
+func testSetTryRemove(t *testing.T, factory func() setx.Set[int]) {
+	set := factory()
+	set.Add(1)
+	set.Add(2)
+	set.Add(3)
+
+	// Remove existing element
+	result := set.TryRemove(2)
+	if result.IsErr() {
+		t.Errorf("Should successfully remove existing element: %v", result.UnwrapErr())
+	}
+	if result.Unwrap() != 2 {
+		t.Errorf("Expected removed element to be 2, got %d", result.Unwrap())
+	}
+
+	if set.Contains(2) {
+		t.Error("Element 2 should be removed from set")
+	}
+
+	// Try to remove non-existing element
+	result = set.TryRemove(10)
+	if result.IsOk() || !errors.Is(result.UnwrapErr(), setx.ErrElementNotFound) {
+		t.Error("Should fail to remove non-existing element with ErrElementNotFound")
+	}
+}
+
+func testSetFilter(t *testing.T, factory func() setx.Set[int]) {
+	set := factory()
+	set.Add(1)
+	set.Add(2)
+	set.Add(3)
+	set.Add(4)
+	set.Add(5)
+
+	// Filter even numbers
+	evenSet := set.Filter(func(x int) bool { return x%2 == 0 })
+
+	if evenSet.Size() != 2 {
+		t.Errorf("Expected 2 even numbers, got %d", evenSet.Size())
+	}
+
+	if !evenSet.Contains(2) || !evenSet.Contains(4) {
+		t.Error("Even set should contain 2 and 4")
+	}
+
+	if evenSet.Contains(1) || evenSet.Contains(3) || evenSet.Contains(5) {
+		t.Error("Even set should not contain odd numbers")
+	}
+
+	// Filter with no matches
+	largeSet := set.Filter(func(x int) bool { return x > 10 })
+	if !largeSet.IsEmpty() {
+		t.Error("Filter with no matches should return empty set")
+	}
+}
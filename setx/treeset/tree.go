@@ -0,0 +1,214 @@
+package treeset
+
+// treeNode is a node of an AVL tree.
+type treeNode[T any] struct {
+	value       T
+	left, right *treeNode[T]
+	height      int
+}
+
+// tree is an AVL tree ordered by cmp. It is shared by a TreeSet and all of its views.
+type tree[T any] struct {
+	root *treeNode[T]
+	size int
+	cmp  func(a, b T) int
+}
+
+func height[T any](n *treeNode[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func (n *treeNode[T]) fix() {
+	n.height = max(height(n.left), height(n.right)) + 1
+}
+
+func rotateRight[T any](n *treeNode[T]) *treeNode[T] {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	n.fix()
+	l.fix()
+	return l
+}
+
+func rotateLeft[T any](n *treeNode[T]) *treeNode[T] {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	n.fix()
+	r.fix()
+	return r
+}
+
+// balance restores the AVL invariant at n after one of its subtrees changed height.
+func balance[T any](n *treeNode[T]) *treeNode[T] {
+	n.fix()
+	switch bf := height(n.left) - height(n.right); {
+	case bf > 1:
+		if height(n.left.left) < height(n.left.right) {
+			n.left = rotateLeft(n.left)
+		}
+		return rotateRight(n)
+	case bf < -1:
+		if height(n.right.right) < height(n.right.left) {
+			n.right = rotateRight(n.right)
+		}
+		return rotateLeft(n)
+	}
+	return n
+}
+
+// insert adds value and reports whether it was not present before.
+func (t *tree[T]) insert(value T) bool {
+	var added bool
+	t.root, added = t.insertAt(t.root, value)
+	if added {
+		t.size++
+	}
+	return added
+}
+
+func (t *tree[T]) insertAt(n *treeNode[T], value T) (*treeNode[T], bool) {
+	if n == nil {
+		return &treeNode[T]{value: value, height: 1}, true
+	}
+	var added bool
+	switch c := t.cmp(value, n.value); {
+	case c < 0:
+		n.left, added = t.insertAt(n.left, value)
+	case c > 0:
+		n.right, added = t.insertAt(n.right, value)
+	default:
+		return n, false
+	}
+	return balance(n), added
+}
+
+// delete removes value and reports whether it was present.
+func (t *tree[T]) delete(value T) bool {
+	var removed bool
+	t.root, removed = t.deleteAt(t.root, value)
+	if removed {
+		t.size--
+	}
+	return removed
+}
+
+func (t *tree[T]) deleteAt(n *treeNode[T], value T) (*treeNode[T], bool) {
+	if n == nil {
+		return nil, false
+	}
+	var removed bool
+	switch c := t.cmp(value, n.value); {
+	case c < 0:
+		n.left, removed = t.deleteAt(n.left, value)
+	case c > 0:
+		n.right, removed = t.deleteAt(n.right, value)
+	default:
+		if n.left == nil {
+			return n.right, true
+		}
+		if n.right == nil {
+			return n.left, true
+		}
+		successor := n.right
+		for successor.left != nil {
+			successor = successor.left
+		}
+		n.value = successor.value
+		n.right, _ = t.deleteAt(n.right, successor.value)
+		removed = true
+	}
+	return balance(n), removed
+}
+
+// find returns the node holding value, or nil.
+func (t *tree[T]) find(value T) *treeNode[T] {
+	n := t.root
+	for n != nil {
+		switch c := t.cmp(value, n.value); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+// min returns the node holding the smallest value, or nil.
+func (t *tree[T]) min() *treeNode[T] {
+	n := t.root
+	if n == nil {
+		return nil
+	}
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+// max returns the node holding the largest value, or nil.
+func (t *tree[T]) max() *treeNode[T] {
+	n := t.root
+	if n == nil {
+		return nil
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n
+}
+
+// ceiling returns the node holding the smallest value >= value, or nil.
+func (t *tree[T]) ceiling(value T) *treeNode[T] {
+	var best *treeNode[T]
+	for n := t.root; n != nil; {
+		switch c := t.cmp(value, n.value); {
+		case c < 0:
+			best = n
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return best
+}
+
+// floor returns the node holding the largest value <= value, or nil.
+func (t *tree[T]) floor(value T) *treeNode[T] {
+	var best *treeNode[T]
+	for n := t.root; n != nil; {
+		switch c := t.cmp(value, n.value); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			best = n
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return best
+}
+
+// lower returns the node holding the largest value < value, or nil.
+func (t *tree[T]) lower(value T) *treeNode[T] {
+	var best *treeNode[T]
+	for n := t.root; n != nil; {
+		if t.cmp(value, n.value) > 0 {
+			best = n
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+	return best
+}
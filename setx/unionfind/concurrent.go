@@ -0,0 +1,99 @@
+package unionfind
+
+import (
+	"sync"
+
+	"github.com/gosuda/stdx/option"
+	"github.com/gosuda/stdx/setx"
+)
+
+// ConcurrentDisjointSet is a thread-safe DisjointSet. Every method is linearizable.
+//
+// A single mutex guards the structure because Find compresses paths and so writes even on lookups.
+type ConcurrentDisjointSet[T comparable] struct {
+	mu sync.Mutex
+	d  *DisjointSet[T]
+}
+
+func NewConcurrent[T comparable]() *ConcurrentDisjointSet[T] {
+	return &ConcurrentDisjointSet[T]{d: New[T]()}
+}
+
+// MakeSet adds element as a singleton set. It returns false if element is already present.
+func (c *ConcurrentDisjointSet[T]) MakeSet(element T) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.d.MakeSet(element)
+}
+
+// Find returns the representative of the set containing element, or None if element is not present.
+func (c *ConcurrentDisjointSet[T]) Find(element T) option.Option[T] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.d.Find(element)
+}
+
+// Union merges the sets containing a and b, adding either as a singleton set first if it is not present.
+// It returns false if a and b were already in the same set.
+func (c *ConcurrentDisjointSet[T]) Union(a, b T) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.d.Union(a, b)
+}
+
+// Connected reports whether a and b are present and in the same set.
+func (c *ConcurrentDisjointSet[T]) Connected(a, b T) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.d.Connected(a, b)
+}
+
+// Contains reports whether element is present.
+func (c *ConcurrentDisjointSet[T]) Contains(element T) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.d.Contains(element)
+}
+
+// SetSize returns the number of elements in the set containing element, or 0 if element is not present.
+func (c *ConcurrentDisjointSet[T]) SetSize(element T) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.d.SetSize(element)
+}
+
+// Len returns the number of elements.
+func (c *ConcurrentDisjointSet[T]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.d.Len()
+}
+
+// Count returns the number of disjoint sets.
+func (c *ConcurrentDisjointSet[T]) Count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.d.Count()
+}
+
+// Component returns the elements of the set containing element as a new set,
+// or an empty set if element is not present.
+func (c *ConcurrentDisjointSet[T]) Component(element T) setx.Set[T] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.d.Component(element)
+}
+
+// Components returns every disjoint set as a new set, in order of the first-added element of each.
+func (c *ConcurrentDisjointSet[T]) Components() []setx.Set[T] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.d.Components()
+}
+
+// Clear removes all elements.
+func (c *ConcurrentDisjointSet[T]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.d.Clear()
+}
@@ -0,0 +1,174 @@
+// Package unionfind provides a disjoint-set (union-find) structure for partitioning elements into groups.
+package unionfind
+
+import (
+	"github.com/gosuda/stdx/option"
+	"github.com/gosuda/stdx/setx"
+	"github.com/gosuda/stdx/setx/hashset"
+)
+
+// DisjointSet partitions elements into disjoint sets. It uses path compression and union by rank,
+// so Find and Union run in amortized near-constant time. It is not safe for concurrent use.
+//
+// Elements are stored in slices indexed by insertion order; parent links and ranks refer to those indexes.
+type DisjointSet[T comparable] struct {
+	index    map[T]int
+	elements []T
+	parent   []int
+	rank     []uint8
+	size     []int
+	count    int
+}
+
+func New[T comparable]() *DisjointSet[T] {
+	return &DisjointSet[T]{
+		index: make(map[T]int),
+	}
+}
+
+// MakeSet adds element as a singleton set. It returns false if element is already present.
+func (d *DisjointSet[T]) MakeSet(element T) bool {
+	if _, exists := d.index[element]; exists {
+		return false
+	}
+	d.add(element)
+	return true
+}
+
+// add inserts element as a singleton set and returns its index.
+func (d *DisjointSet[T]) add(element T) int {
+	i := len(d.elements)
+	d.index[element] = i
+	d.elements = append(d.elements, element)
+	d.parent = append(d.parent, i)
+	d.rank = append(d.rank, 0)
+	d.size = append(d.size, 1)
+	d.count++
+	return i
+}
+
+// indexOf returns the index of element, adding it as a singleton set if it is not present.
+func (d *DisjointSet[T]) indexOf(element T) int {
+	if i, exists := d.index[element]; exists {
+		return i
+	}
+	return d.add(element)
+}
+
+// root returns the index of the representative of i and compresses the path to it.
+func (d *DisjointSet[T]) root(i int) int {
+	r := i
+	for d.parent[r] != r {
+		r = d.parent[r]
+	}
+	for d.parent[i] != r {
+		d.parent[i], i = r, d.parent[i]
+	}
+	return r
+}
+
+// Find returns the representative of the set containing element, or None if element is not present.
+// Two elements are in the same set exactly when they have the same representative.
+func (d *DisjointSet[T]) Find(element T) option.Option[T] {
+	i, exists := d.index[element]
+	if !exists {
+		return option.None[T]()
+	}
+	return option.Some(d.elements[d.root(i)])
+}
+
+// Union merges the sets containing a and b, adding either as a singleton set first if it is not present.
+// It returns false if a and b were already in the same set.
+func (d *DisjointSet[T]) Union(a, b T) bool {
+	ra, rb := d.root(d.indexOf(a)), d.root(d.indexOf(b))
+	if ra == rb {
+		return false
+	}
+	if d.rank[ra] < d.rank[rb] {
+		ra, rb = rb, ra
+	}
+	d.parent[rb] = ra
+	d.size[ra] += d.size[rb]
+	if d.rank[ra] == d.rank[rb] {
+		d.rank[ra]++
+	}
+	d.count--
+	return true
+}
+
+// Connected reports whether a and b are present and in the same set.
+func (d *DisjointSet[T]) Connected(a, b T) bool {
+	i, ok := d.index[a]
+	if !ok {
+		return false
+	}
+	j, ok := d.index[b]
+	if !ok {
+		return false
+	}
+	return d.root(i) == d.root(j)
+}
+
+// Contains reports whether element is present.
+func (d *DisjointSet[T]) Contains(element T) bool {
+	_, exists := d.index[element]
+	return exists
+}
+
+// SetSize returns the number of elements in the set containing element, or 0 if element is not present.
+func (d *DisjointSet[T]) SetSize(element T) int {
+	i, exists := d.index[element]
+	if !exists {
+		return 0
+	}
+	return d.size[d.root(i)]
+}
+
+// Len returns the number of elements.
+func (d *DisjointSet[T]) Len() int {
+	return len(d.elements)
+}
+
+// Count returns the number of disjoint sets.
+func (d *DisjointSet[T]) Count() int {
+	return d.count
+}
+
+// Component returns the elements of the set containing element as a new set,
+// or an empty set if element is not present.
+func (d *DisjointSet[T]) Component(element T) setx.Set[T] {
+	result := hashset.New[T]()
+	i, exists := d.index[element]
+	if !exists {
+		return result
+	}
+	r := d.root(i)
+	for j, e := range d.elements {
+		if d.root(j) == r {
+			result.Add(e)
+		}
+	}
+	return result
+}
+
+// Components returns every disjoint set as a new set, in order of the first-added element of each.
+func (d *DisjointSet[T]) Components() []setx.Set[T] {
+	components := make([]setx.Set[T], 0, d.count)
+	position := make(map[int]int, d.count)
+	for i, e := range d.elements {
+		r := d.root(i)
+		p, exists := position[r]
+		if !exists {
+			p = len(components)
+			position[r] = p
+			components = append(components, hashset.New[T]())
+		}
+		components[p].Add(e)
+	}
+	return components
+}
+
+// Clear removes all elements.
+func (d *DisjointSet[T]) Clear() {
+	*d = *New[T]()
+}
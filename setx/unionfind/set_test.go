@@ -0,0 +1,153 @@
+package unionfind_test
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/gosuda/stdx/setx"
+	"github.com/gosuda/stdx/setx/unionfind"
+)
+
+// disjointSet is the API shared by DisjointSet and ConcurrentDisjointSet.
+type disjointSet interface {
+	MakeSet(element int) bool
+	Union(a, b int) bool
+	Connected(a, b int) bool
+	SetSize(element int) int
+	Len() int
+	Count() int
+	Component(element int) setx.Set[int]
+	Components() []setx.Set[int]
+	Clear()
+}
+
+func sorted(elements []int) []int {
+	slices.Sort(elements)
+	return elements
+}
+
+func testDisjointSet(t *testing.T, d disjointSet) {
+	for i := range 6 {
+		if !d.MakeSet(i) {
+			t.Errorf("MakeSet(%d) should add a new element", i)
+		}
+	}
+	if d.MakeSet(0) {
+		t.Error("MakeSet of an existing element should return false")
+	}
+	if d.Len() != 6 || d.Count() != 6 {
+		t.Errorf("Expected 6 singleton sets, got %d elements in %d sets", d.Len(), d.Count())
+	}
+
+	if !d.Union(0, 1) || !d.Union(2, 3) || !d.Union(1, 3) {
+		t.Error("Union of disjoint sets should return true")
+	}
+	if d.Union(0, 2) {
+		t.Error("Union of connected elements should return false")
+	}
+	if !d.Connected(0, 3) || d.Connected(0, 4) {
+		t.Error("Unexpected connectivity")
+	}
+	if d.Connected(0, 42) || d.Connected(42, 42) {
+		t.Error("Missing elements should not be connected")
+	}
+	if d.SetSize(2) != 4 || d.SetSize(5) != 1 || d.SetSize(42) != 0 {
+		t.Errorf("Unexpected set sizes %d, %d, %d", d.SetSize(2), d.SetSize(5), d.SetSize(42))
+	}
+	if d.Count() != 3 {
+		t.Errorf("Expected 3 sets, got %d", d.Count())
+	}
+
+	if !d.Union(6, 5) || d.Len() != 7 || !d.Connected(5, 6) {
+		t.Error("Union should add missing elements")
+	}
+
+	if got := sorted(d.Component(3).ToSlice()); !slices.Equal(got, []int{0, 1, 2, 3}) {
+		t.Errorf("Unexpected component %v", got)
+	}
+	if !d.Component(42).IsEmpty() {
+		t.Error("Component of a missing element should be empty")
+	}
+
+	components := d.Components()
+	var got [][]int
+	for _, c := range components {
+		got = append(got, sorted(c.ToSlice()))
+	}
+	want := [][]int{{0, 1, 2, 3}, {4}, {5, 6}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("Expected components %v, got %v", want, got)
+	}
+
+	d.Clear()
+	if d.Len() != 0 || d.Count() != 0 || len(d.Components()) != 0 {
+		t.Error("Clear should remove all elements")
+	}
+}
+
+func TestDisjointSet(t *testing.T) {
+	testDisjointSet(t, unionfind.New[int]())
+}
+
+func TestConcurrentDisjointSet(t *testing.T) {
+	testDisjointSet(t, unionfind.NewConcurrent[int]())
+}
+
+func TestDisjointSet_Find(t *testing.T) {
+	d := unionfind.New[string]()
+	if d.Find("a").IsSome() {
+		t.Error("Find of a missing element should return None")
+	}
+
+	d.Union("a", "b")
+	d.Union("c", "d")
+	d.Union("b", "d")
+	root := d.Find("a").Unwrap()
+	for _, e := range []string{"b", "c", "d"} {
+		if r := d.Find(e).Unwrap(); r != root {
+			t.Errorf("Expected representative %q for %q, got %q", root, e, r)
+		}
+	}
+	if !d.Contains("a") || d.Contains("z") {
+		t.Error("Unexpected Contains result")
+	}
+}
+
+func TestDisjointSet_Chain(t *testing.T) {
+	const n = 10000
+	d := unionfind.New[int]()
+	for i := 1; i < n; i++ {
+		d.Union(i-1, i)
+	}
+	if d.Count() != 1 || d.SetSize(0) != n || !d.Connected(0, n-1) {
+		t.Errorf("Expected one set of %d elements, got %d sets", n, d.Count())
+	}
+}
+
+func TestConcurrentDisjointSet_ConcurrentUnion(t *testing.T) {
+	const goroutines, n = 8, 1000
+	d := unionfind.NewConcurrent[int]()
+
+	var wg sync.WaitGroup
+	for g := range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := g; i+goroutines < n; i += goroutines {
+				d.Union(i, i+goroutines)
+				d.Connected(i, 0)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if d.Count() != goroutines {
+		t.Errorf("Expected %d sets, got %d", goroutines, d.Count())
+	}
+	for g := range goroutines {
+		if d.SetSize(g) != n/goroutines {
+			t.Errorf("Expected set of %d to have %d elements, got %d", g, n/goroutines, d.SetSize(g))
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package synx
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gosuda/stdx/option"
+	"github.com/gosuda/stdx/result"
+)
+
+// ErrNoFutures is the error of Any and Race when called without futures
+var ErrNoFutures = errors.New("synx: no futures")
+
+// Future is a value of type result.Result[T, error] that becomes available later.
+// A Future is completed exactly once; later attempts to complete it are ignored.
+//
+// Catch, Then, All, Any and Race wait for their inputs on goroutines that stay parked
+// until those inputs complete, even after the returned Future has completed.
+// A Future that never completes therefore leaks the goroutines waiting on it
+type Future[T any] struct {
+	once   sync.Once
+	done   chan struct{}
+	r      result.Result[T, error]
+	cancel context.CancelFunc
+}
+
+// newFuture creates a pending Future
+func newFuture[T any]() *Future[T] {
+	return &Future[T]{done: make(chan struct{})}
+}
+
+// complete stores r and wakes up all waiters if the Future is still pending.
+// It reports whether r was stored
+func (f *Future[T]) complete(r result.Result[T, error]) bool {
+	completed := false
+	f.once.Do(func() {
+		f.r = r
+		close(f.done)
+		completed = true
+	})
+	return completed
+}
+
+// NewFuture runs fn in a new goroutine and returns a Future of its result.
+// fn receives a context derived from ctx that is cancelled by Cancel or when ctx is done;
+// the Future then completes immediately with the context's error, without waiting for fn.
+// A panic in fn completes the Future with a *result.PanicError
+func NewFuture[T any](ctx context.Context, fn func(ctx context.Context) (T, error)) *Future[T] {
+	ctx, cancel := context.WithCancel(ctx)
+	f := newFuture[T]()
+	f.cancel = cancel
+	// finished keeps a cancellation that arrives after fn returned, but before stop
+	// unregisters the callback, from replacing the result of fn
+	var finished atomic.Bool
+	stop := context.AfterFunc(ctx, func() {
+		if !finished.Load() {
+			f.complete(result.Err[T, error](ctx.Err()))
+		}
+	})
+	go func() {
+		r := result.Catch(func() (T, error) { return fn(ctx) })
+		finished.Store(true)
+		stop()
+		f.complete(r)
+		cancel()
+	}()
+	return f
+}
+
+// Resolved returns a Future that is already completed with Ok(value)
+func Resolved[T any](value T) *Future[T] {
+	f := newFuture[T]()
+	f.complete(result.Ok[T, error](value))
+	return f
+}
+
+// Rejected returns a Future that is already completed with Err(err)
+func Rejected[T any](err error) *Future[T] {
+	f := newFuture[T]()
+	f.complete(result.Err[T, error](err))
+	return f
+}
+
+// Await waits for the Future to complete and returns its result.
+// If ctx is done first, it returns Err(ctx.Err()) and the Future keeps running
+func (f *Future[T]) Await(ctx context.Context) result.Result[T, error] {
+	select {
+	case <-f.done:
+		return f.r
+	case <-ctx.Done():
+		return result.Err[T, error](ctx.Err())
+	}
+}
+
+// Done returns a channel that is closed when the Future completes
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Poll returns the result if the Future has completed, or None if it is still pending
+func (f *Future[T]) Poll() option.Option[result.Result[T, error]] {
+	select {
+	case <-f.done:
+		return option.Some(f.r)
+	default:
+		return option.None[result.Result[T, error]]()
+	}
+}
+
+// Cancel completes a pending Future with Err(context.Canceled).
+// For a Future created by NewFuture it also cancels the context passed to fn
+func (f *Future[T]) Cancel() {
+	if f.cancel != nil {
+		f.cancel()
+	}
+	f.complete(result.Err[T, error](context.Canceled))
+}
+
+// Catch returns a Future that completes with the result of fn if f fails, or with the value of f otherwise.
+// A goroutine waits for f until it completes
+func (f *Future[T]) Catch(fn func(err error) (T, error)) *Future[T] {
+	next := newFuture[T]()
+	go func() {
+		<-f.done
+		if f.r.IsOk() {
+			next.complete(f.r)
+			return
+		}
+		err := f.r.UnwrapErr()
+		next.complete(result.Catch(func() (T, error) { return fn(err) }))
+	}()
+	return next
+}
+
+// Then returns a Future that completes with the result of fn applied to the value of f,
+// or with the error of f if it fails. A goroutine waits for f until it completes
+func Then[T, U any](f *Future[T], fn func(value T) (U, error)) *Future[U] {
+	next := newFuture[U]()
+	go func() {
+		<-f.done
+		if f.r.IsErr() {
+			next.complete(result.Err[U, error](f.r.UnwrapErr()))
+			return
+		}
+		value := f.r.Unwrap()
+		next.complete(result.Catch(func() (U, error) { return fn(value) }))
+	}()
+	return next
+}
+
+// settled returns a channel that receives the index of each future as it completes
+func settled[T any](futures []*Future[T]) <-chan int {
+	ch := make(chan int, len(futures))
+	for i, f := range futures {
+		go func() {
+			<-f.done
+			ch <- i
+		}()
+	}
+	return ch
+}
+
+// All returns a Future of the values of all futures, in order.
+// It fails with the first error to occur, without waiting for the remaining futures;
+// one goroutine per future still waits until that future completes
+func All[T any](futures ...*Future[T]) *Future[[]T] {
+	all := newFuture[[]T]()
+	go func() {
+		ch := settled(futures)
+		for range futures {
+			if f := futures[<-ch]; f.r.IsErr() {
+				all.complete(result.Err[[]T, error](f.r.UnwrapErr()))
+				return
+			}
+		}
+		values := make([]T, len(futures))
+		for i, f := range futures {
+			values[i] = f.r.Unwrap()
+		}
+		all.complete(result.Ok[[]T, error](values))
+	}()
+	return all
+}
+
+// Any returns a Future of the first value among futures to succeed.
+// If all fail, it fails with their errors joined in order by errors.Join.
+// One goroutine per future waits until that future completes, even after a value is found
+func Any[T any](futures ...*Future[T]) *Future[T] {
+	if len(futures) == 0 {
+		return Rejected[T](ErrNoFutures)
+	}
+	first := newFuture[T]()
+	go func() {
+		ch := settled(futures)
+		for range futures {
+			if f := futures[<-ch]; f.r.IsOk() {
+				first.complete(f.r)
+				return
+			}
+		}
+		errs := make([]error, len(futures))
+		for i, f := range futures {
+			errs[i] = f.r.UnwrapErr()
+		}
+		first.complete(result.Err[T, error](errors.Join(errs...)))
+	}()
+	return first
+}
+
+// Race returns a Future that completes like the first of futures to complete, whether it succeeds or fails.
+// One goroutine per future waits until that future completes, even after the race is decided
+func Race[T any](futures ...*Future[T]) *Future[T] {
+	if len(futures) == 0 {
+		return Rejected[T](ErrNoFutures)
+	}
+	first := newFuture[T]()
+	go func() {
+		first.complete(futures[<-settled(futures)].r)
+	}()
+	return first
+}
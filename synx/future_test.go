@@ -0,0 +1,216 @@
+package synx
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gosuda/stdx/result"
+)
+
+// after returns a Future that completes with value, or with err if it is not nil, after d
+func after[T any](d time.Duration, value T, err error) *Future[T] {
+	return NewFuture(context.Background(), func(ctx context.Context) (T, error) {
+		select {
+		case <-time.After(d):
+			return value, err
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	})
+}
+
+func TestFuture(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Await", func(t *testing.T) {
+		f := NewFuture(ctx, func(context.Context) (int, error) { return 42, nil })
+		if r := f.Await(ctx); !r.IsOk() || r.Unwrap() != 42 {
+			t.Errorf("Expected Ok(42), got %v", r)
+		}
+		if r := f.Await(ctx); r.Unwrap() != 42 {
+			t.Error("Await should return the same result every time")
+		}
+		select {
+		case <-f.Done():
+		default:
+			t.Error("Done should be closed after completion")
+		}
+	})
+
+	t.Run("Error and panic", func(t *testing.T) {
+		errBoom := errors.New("boom")
+		f := NewFuture(ctx, func(context.Context) (int, error) { return 0, errBoom })
+		if r := f.Await(ctx); !result.ErrIs(r, errBoom) {
+			t.Errorf("Expected Err(boom), got %v", r)
+		}
+
+		p := NewFuture(ctx, func(context.Context) (int, error) { panic("bad") })
+		if _, ok := result.IsErrAs[*result.PanicError](p.Await(ctx)); !ok {
+			t.Error("Expected a panic to complete the Future with a PanicError")
+		}
+	})
+
+	t.Run("Await timeout", func(t *testing.T) {
+		f := after(time.Hour, 1, nil)
+		defer f.Cancel()
+
+		timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		if r := f.Await(timeout); !result.ErrIs(r, context.DeadlineExceeded) {
+			t.Errorf("Expected DeadlineExceeded, got %v", r)
+		}
+		if f.Poll().IsSome() {
+			t.Error("Await timing out should not complete the Future")
+		}
+	})
+
+	t.Run("Poll", func(t *testing.T) {
+		release := make(chan struct{})
+		f := NewFuture(ctx, func(context.Context) (string, error) {
+			<-release
+			return "done", nil
+		})
+		if f.Poll().IsSome() {
+			t.Error("Poll should return None while pending")
+		}
+		close(release)
+		f.Await(ctx)
+		if r := f.Poll(); r.IsNone() || r.Unwrap().Unwrap() != "done" {
+			t.Errorf("Expected Some(Ok(done)), got %v", r)
+		}
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		var observed atomic.Bool
+		stopped := make(chan struct{})
+		f := NewFuture(ctx, func(ctx context.Context) (int, error) {
+			defer close(stopped)
+			<-ctx.Done()
+			observed.Store(true)
+			return 0, ctx.Err()
+		})
+		f.Cancel()
+		if r := f.Poll(); r.IsNone() || !result.ErrIs(r.Unwrap(), context.Canceled) {
+			t.Errorf("Expected Cancel to complete the Future with Canceled, got %v", r)
+		}
+		<-stopped
+		if !observed.Load() {
+			t.Error("Cancel should cancel the context passed to fn")
+		}
+
+		done := Resolved(1)
+		done.Cancel()
+		if r := done.Await(ctx); r.Unwrap() != 1 {
+			t.Error("Cancel should not affect a completed Future")
+		}
+	})
+
+	t.Run("Parent context", func(t *testing.T) {
+		parent, cancel := context.WithCancel(ctx)
+		f := NewFuture(parent, func(ctx context.Context) (int, error) {
+			select {}
+		})
+		cancel()
+		if r := f.Await(ctx); !result.ErrIs(r, context.Canceled) {
+			t.Errorf("Expected cancelling the parent context to complete the Future, got %v", r)
+		}
+	})
+}
+
+func TestFuture_Combinators(t *testing.T) {
+	ctx := context.Background()
+	errBoom := errors.New("boom")
+
+	t.Run("Then", func(t *testing.T) {
+		f := Then(Resolved(21), func(v int) (string, error) {
+			if v*2 != 42 {
+				return "", errBoom
+			}
+			return "forty-two", nil
+		})
+		if r := f.Await(ctx); r.UnwrapOr("") != "forty-two" {
+			t.Errorf("Expected Ok(forty-two), got %v", r)
+		}
+
+		called := false
+		failed := Then(Rejected[int](errBoom), func(int) (string, error) {
+			called = true
+			return "", nil
+		})
+		if r := failed.Await(ctx); !result.ErrIs(r, errBoom) || called {
+			t.Errorf("Expected the error to propagate without calling fn, got %v", r)
+		}
+	})
+
+	t.Run("Catch", func(t *testing.T) {
+		recovered := Rejected[int](errBoom).Catch(func(err error) (int, error) {
+			if !errors.Is(err, errBoom) {
+				return 0, err
+			}
+			return -1, nil
+		})
+		if r := recovered.Await(ctx); r.UnwrapOr(0) != -1 {
+			t.Errorf("Expected Ok(-1), got %v", r)
+		}
+
+		ok := Resolved(1).Catch(func(error) (int, error) { return -1, nil })
+		if r := ok.Await(ctx); r.UnwrapOr(0) != 1 {
+			t.Errorf("Expected Catch to pass Ok through, got %v", r)
+		}
+	})
+
+	t.Run("All", func(t *testing.T) {
+		all := All(after(20*time.Millisecond, 1, nil), Resolved(2), after(5*time.Millisecond, 3, nil))
+		if r := all.Await(ctx); r.IsErr() || !slices.Equal(r.Unwrap(), []int{1, 2, 3}) {
+			t.Errorf("Expected Ok([1 2 3]), got %v", r)
+		}
+
+		slow := after(time.Hour, 1, nil)
+		defer slow.Cancel()
+		failed := All(slow, Rejected[int](errBoom))
+		if r := failed.Await(ctx); !result.ErrIs(r, errBoom) {
+			t.Errorf("Expected All to fail fast with boom, got %v", r)
+		}
+
+		if r := All[int]().Await(ctx); r.IsErr() || len(r.Unwrap()) != 0 {
+			t.Errorf("Expected Ok([]) for no futures, got %v", r)
+		}
+	})
+
+	t.Run("Any", func(t *testing.T) {
+		first := Any(Rejected[int](errBoom), after(5*time.Millisecond, 2, nil), after(time.Hour, 3, nil))
+		if r := first.Await(ctx); r.UnwrapOr(0) != 2 {
+			t.Errorf("Expected Ok(2), got %v", r)
+		}
+
+		errOther := errors.New("other")
+		none := Any(Rejected[int](errBoom), Rejected[int](errOther))
+		r := none.Await(ctx)
+		if !result.ErrIs(r, errBoom) || !result.ErrIs(r, errOther) {
+			t.Errorf("Expected all errors joined, got %v", r)
+		}
+
+		if r := Any[int]().Await(ctx); !result.ErrIs(r, ErrNoFutures) {
+			t.Errorf("Expected ErrNoFutures, got %v", r)
+		}
+	})
+
+	t.Run("Race", func(t *testing.T) {
+		slow := after(time.Hour, 1, nil)
+		defer slow.Cancel()
+		if r := Race(slow, after(5*time.Millisecond, 0, errBoom)).Await(ctx); !result.ErrIs(r, errBoom) {
+			t.Errorf("Expected the first completion to win, got %v", r)
+		}
+		if r := Race(slow, Resolved(7)).Await(ctx); r.UnwrapOr(0) != 7 {
+			t.Errorf("Expected Ok(7), got %v", r)
+		}
+		if r := Race[int]().Await(ctx); !result.ErrIs(r, ErrNoFutures) {
+			t.Errorf("Expected ErrNoFutures, got %v", r)
+		}
+	})
+}